@@ -0,0 +1,66 @@
+package semver
+
+import "sync"
+
+// AtomicVersion holds a version that can be read and updated from multiple goroutines.
+// It stores a private copy of every version it is given and hands out copies,
+// so changes made through the pointers passed in or returned can never race with other readers.
+// The zero value holds no version.
+type AtomicVersion struct {
+	mu      sync.RWMutex
+	version *Version
+}
+
+func NewAtomicVersion(v *Version) *AtomicVersion {
+	return &AtomicVersion{
+		version: v.Clone(),
+	}
+}
+
+// Load returns a copy of the current version, or nil if no version is set.
+func (a *AtomicVersion) Load() *Version {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.version.Clone()
+}
+
+// Store sets the version to a copy of v.
+func (a *AtomicVersion) Store(v *Version) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.version = v.Clone()
+}
+
+// Get is an alias for Load.
+func (a *AtomicVersion) Get() *Version {
+	return a.Load()
+}
+
+// Set is an alias for Store.
+func (a *AtomicVersion) Set(v *Version) {
+	a.Store(v)
+}
+
+// CompareAndSwap sets the version to new if the current version equals old
+// and reports whether it did. A nil old only matches if no version is set.
+func (a *AtomicVersion) CompareAndSwap(old, new *Version) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if (a.version == nil) != (old == nil) || (old != nil && compare(a.version, old) != 0) {
+		return false
+	}
+	a.version = new.Clone()
+	return true
+}
+
+// Upgrade sets the version to new if it is greater than the current version,
+// or if no version is set, and reports whether it did.
+func (a *AtomicVersion) Upgrade(new *Version) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if new == nil || (a.version != nil && compare(new, a.version) <= 0) {
+		return false
+	}
+	a.version = new.Clone()
+	return true
+}
@@ -0,0 +1,90 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Builder assembles a version from untrusted input. Unlike the chainable setters of Version
+// it neither clamps nor records values while chaining; all fields are validated by Build.
+// A Builder can be reused after Build, e.g. to build several versions that share fields.
+type Builder struct {
+	major      int
+	minor      int
+	patch      int
+	prerelease []string
+	metadata   []string
+}
+
+// NewBuilder returns a Builder for v0.0.0.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// Major sets the major version.
+func (b *Builder) Major(n int) *Builder {
+	b.major = n
+	return b
+}
+
+// Minor sets the minor version.
+func (b *Builder) Minor(n int) *Builder {
+	b.minor = n
+	return b
+}
+
+// Patch sets the patch version.
+func (b *Builder) Patch(n int) *Builder {
+	b.patch = n
+	return b
+}
+
+// Prerelease replaces the pre-release identifiers, e.g. Prerelease("rc", "1") for "-rc.1".
+func (b *Builder) Prerelease(ids ...string) *Builder {
+	b.prerelease = append([]string(nil), ids...)
+	return b
+}
+
+// Metadata replaces the build metadata identifiers, e.g. Metadata("sha", "abc123") for "+sha.abc123".
+func (b *Builder) Metadata(ids ...string) *Builder {
+	b.metadata = append([]string(nil), ids...)
+	return b
+}
+
+// Build validates all fields and returns the version, or nil and all problems joined into one error.
+func (b *Builder) Build() (*Version, error) {
+	suffix := strings.Join(b.prerelease, ".")
+	metadata := strings.Join(b.metadata, ".")
+	err := errors.Join(
+		checkComponent("major", b.major),
+		checkComponent("minor", b.minor),
+		checkComponent("patch", b.patch),
+		validateIdentifierList("pre-release", b.prerelease, false),
+		validateIdentifierList("metadata", b.metadata, true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Version{
+		major:     b.major,
+		minor:     b.minor,
+		patch:     b.patch,
+		suffix:    suffix,
+		metadata:  metadata,
+		precision: 3, // all components are set explicitly, like with SetPatch
+	}, nil
+}
+
+// validateIdentifierList validates identifiers passed individually, so an empty one or one containing a dot is an error.
+func validateIdentifierList(kind string, ids []string, allowLeadingZeros bool) error {
+	for _, id := range ids {
+		if id == "" || strings.Contains(id, ".") {
+			return fmt.Errorf("invalid %s identifier %q", kind, id)
+		}
+		if err := validateIdentifiers(kind, id, allowLeadingZeros); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,398 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+var constraintOperators = []string{">=", "<=", "!=", ">", "<", "=", "^"}
+
+type constraintTerm struct {
+	op      string
+	version *Version
+}
+
+func (t constraintTerm) check(v *Version) bool {
+	c := compare(v, t.version)
+	switch t.op {
+	case "=":
+		return c == 0
+	case "!=":
+		return c != 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	}
+	return false
+}
+
+// Constraint is a set of comparisons that a version must all satisfy, e.g. ">=1.2.0 <2.0.0".
+type Constraint struct {
+	terms []constraintTerm
+}
+
+// NewConstraint parses a whitespace separated list of comparisons.
+// Supported operators are =, !=, >, >=, < and <=, a version without operator must match exactly.
+// A caret allows changes that don't modify the left-most non-zero component, e.g. "^1.2.3" is expanded
+// to ">=1.2.3 <2.0.0-0", which excludes the pre-releases of 2.0.0, and "^0.2.3" to ">=0.2.3 <0.3.0-0".
+// "*" matches every version. Every invalid version in expr is reported as *InvalidConstraintError,
+// joined into one error if there are several.
+func NewConstraint(expr string) (*Constraint, error) {
+	tokens, err := tokenizeConstraint(expr)
+	if err != nil {
+		return nil, err
+	}
+	c := &Constraint{
+		terms: []constraintTerm{},
+	}
+	var errs []error
+	for _, t := range tokens {
+		version, err := parseConstraintVersion(t.version)
+		if err != nil {
+			errs = append(errs, invalidConstraintVersion(expr, t))
+			continue
+		}
+		if t.op == "^" {
+			c.terms = append(c.terms, constraintTerm{op: ">=", version: version}, constraintTerm{op: "<", version: caretUpperBound(version)})
+			continue
+		}
+		c.terms = append(c.terms, constraintTerm{op: t.op, version: version})
+	}
+	if err := joinConstraintErrors(errs); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// caretUpperBound returns the exclusive upper bound of "^v", with the "-0" suffix so the pre-releases
+// of the bound are excluded as well. The left-most non-zero component is the breaking one, like in
+// npm and cargo:
+//
+//	^1.2.3 := >=1.2.3 <2.0.0-0
+//	^0.2.3 := >=0.2.3 <0.3.0-0 (minor is the breaking boundary before 1.0.0)
+//	^0.0.3 := >=0.0.3 <0.0.4-0 (patch is the breaking boundary before 0.1.0)
+//
+// If all given components are zero, the last given one is the boundary:
+//
+//	^0.0.0 := >=0.0.0 <0.0.1-0
+//	^0.0   := >=0.0.0 <0.1.0-0
+//	^0     := >=0.0.0 <1.0.0-0
+func caretUpperBound(v *Version) *Version {
+	precision := max(v.precision, 1)
+	switch {
+	case v.major > 0 || precision == 1:
+		return NewFromInts(v.major+1, 0, 0).SetSuffix("0")
+	case v.minor > 0 || precision == 2:
+		return NewFromInts(0, v.minor+1, 0).SetSuffix("0")
+	}
+	return NewFromInts(0, 0, v.patch+1).SetSuffix("0")
+}
+
+// ValidateConstraintExpr checks whether expr is a valid constraint without building it.
+// It accepts exactly the expressions NewConstraint accepts.
+// The returned error is an *InvalidConstraintError holding the offending token and its offset,
+// or, like for NewConstraint, all of them joined if the expression contains several invalid versions.
+func ValidateConstraintExpr(expr string) error {
+	tokens, err := tokenizeConstraint(expr)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, t := range tokens {
+		if _, err := parseConstraintVersion(t.version); err != nil {
+			errs = append(errs, invalidConstraintVersion(expr, t))
+		}
+	}
+	return joinConstraintErrors(errs)
+}
+
+func invalidConstraintVersion(expr string, t constraintToken) error {
+	return &InvalidConstraintError{Expr: expr, Reason: fmt.Sprintf("invalid version %q", t.version), Token: t.version, Offset: t.offset}
+}
+
+// joinConstraintErrors returns nil, the only error or all errors joined, so a single problem
+// can still be asserted as *InvalidConstraintError directly.
+func joinConstraintErrors(errs []error) error {
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
+
+type constraintToken struct {
+	op      string
+	version string
+	offset  int // offset of the version in the expression
+}
+
+type constraintField struct {
+	text   string
+	offset int
+}
+
+func constraintFields(expr string) []constraintField {
+	fields := []constraintField{}
+	start := -1
+	for i := 0; i <= len(expr); i++ {
+		if i == len(expr) || unicode.IsSpace(rune(expr[i])) {
+			if start >= 0 {
+				fields = append(fields, constraintField{text: expr[start:i], offset: start})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	return fields
+}
+
+func tokenizeConstraint(expr string) ([]constraintToken, error) {
+	fields := constraintFields(expr)
+	if len(fields) == 0 {
+		return nil, &InvalidConstraintError{Expr: expr, Reason: "empty constraint"}
+	}
+	tokens := []constraintToken{}
+	for i := 0; i < len(fields); i++ {
+		if fields[i].text == "*" { // matches every version, so it adds no term
+			continue
+		}
+		op, str := splitOperator(fields[i].text)
+		offset := fields[i].offset + len(fields[i].text) - len(str)
+		if str == "" && i+1 < len(fields) { // operator separated from its version by whitespace
+			i++
+			str, offset = fields[i].text, fields[i].offset
+		}
+		if str == "" {
+			return nil, &InvalidConstraintError{Expr: expr, Reason: fmt.Sprintf("missing version after %q", op), Token: op, Offset: fields[i].offset}
+		}
+		tokens = append(tokens, constraintToken{op: op, version: str, offset: offset})
+	}
+	return tokens, nil
+}
+
+// parseConstraintVersion parses the version of a constraint token. It is shared by NewConstraint
+// and ValidateConstraintExpr so both accept exactly the same versions.
+func parseConstraintVersion(str string) (*Version, error) {
+	return NewFromStringWithOptions(str, ParseOptions{AllowLeadingV: true})
+}
+
+func splitOperator(str string) (op, version string) {
+	for _, o := range constraintOperators {
+		if strings.HasPrefix(str, o) {
+			return o, str[len(o):]
+		}
+	}
+	return "=", str
+}
+
+// String returns the constraint in normalized form, e.g. ">=1.2.0 <2.0.0", with all components,
+// carets expanded to their lower and upper bound, e.g. ">=1.2.3 <2.0.0-0" for "^1.2.3",
+// single spaces and duplicate terms removed. A constraint without terms matches every version
+// and is rendered as "*". NewConstraint parses the result back into an equivalent constraint.
+func (c *Constraint) String() string {
+	if len(c.terms) == 0 {
+		return "*"
+	}
+	terms := []string{}
+	seen := map[string]bool{}
+	for _, t := range c.terms {
+		term := t.op + t.version.StringWithOptions(FormatOptions{OmitPrefix: true, AllComponents: true})
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return strings.Join(terms, " ")
+}
+
+// Check reports whether v satisfies the constraint.
+func (c *Constraint) Check(v *Version) bool {
+	if v == nil {
+		return false
+	}
+	for _, t := range c.terms {
+		if !t.check(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the versions that satisfy the constraint, preserving their order.
+func (c *Constraint) Filter(versions []*Version) []*Version {
+	return filter(versions, c.Check)
+}
+
+// Latest returns the highest version that satisfies the constraint, or nil if none does.
+func (c *Constraint) Latest(versions []*Version) *Version {
+	return Max(c.Filter(versions))
+}
+
+// Checker is implemented by Constraint and ConstraintSet.
+type Checker interface {
+	Check(v *Version) bool
+}
+
+// CombinationMode defines how a ConstraintSet combines its constraints.
+type CombinationMode int
+
+const (
+	ModeAND CombinationMode = iota // all constraints must be satisfied
+	ModeOR                         // at least one constraint must be satisfied
+)
+
+// ConstraintSet combines constraints, or other sets, with AND or OR.
+type ConstraintSet struct {
+	mode        CombinationMode
+	constraints []Checker
+}
+
+// NewConstraintSetAND returns a set that is satisfied if all of its constraints are.
+func NewConstraintSetAND(constraints ...Checker) *ConstraintSet {
+	return &ConstraintSet{
+		mode:        ModeAND,
+		constraints: constraints,
+	}
+}
+
+// NewConstraintSetOR returns a set that is satisfied if at least one of its constraints is.
+func NewConstraintSetOR(constraints ...Checker) *ConstraintSet {
+	return &ConstraintSet{
+		mode:        ModeOR,
+		constraints: constraints,
+	}
+}
+
+func (s *ConstraintSet) Mode() CombinationMode {
+	return s.mode
+}
+
+// Check reports whether v satisfies the set. An empty AND set is always satisfied, an empty OR set never.
+func (s *ConstraintSet) Check(v *Version) bool {
+	for _, c := range s.constraints {
+		if c.Check(v) == (s.mode == ModeOR) {
+			return s.mode == ModeOR
+		}
+	}
+	return s.mode == ModeAND
+}
+
+// Upgrade returns the highest version that satisfies constraint and is newer than current,
+// or nil if current is already up to date. It returns an error if the constraint is invalid
+// or if none of the versions satisfy it.
+func Upgrade(versions []*Version, current *Version, constraint string) (*Version, error) {
+	c, err := NewConstraint(constraint)
+	if err != nil {
+		return nil, err
+	}
+	latest := c.Latest(versions)
+	if latest == nil {
+		return nil, fmt.Errorf("no version satisfies %s", c)
+	}
+	if current != nil && compare(latest, current) <= 0 {
+		return nil, nil
+	}
+	return latest, nil
+}
+
+// ConstraintFromVersions returns a constraint equivalent to ">=min <=max", or the exclusive
+// variants ">min" and "<max". A nil bound leaves that side unbounded. Build metadata is dropped
+// since constraints don't support it.
+// String only round-trips through NewConstraint if the bounds are spec-valid; a suffix parsed
+// leniently, e.g. by MustParse("1.2.3-a b"), is kept as is. Check bounds with ValidateSuffix first
+// if they come from untrusted input.
+func ConstraintFromVersions(min, max *Version, includeMin, includeMax bool) *Constraint {
+	c := &Constraint{
+		terms: []constraintTerm{},
+	}
+	bound := func(v *Version, op string) {
+		if v == nil {
+			return
+		}
+		version := v.clone()
+		version.metadata = ""
+		c.terms = append(c.terms, constraintTerm{op: op, version: version})
+	}
+	if includeMin {
+		bound(min, ">=")
+	} else {
+		bound(min, ">")
+	}
+	if includeMax {
+		bound(max, "<=")
+	} else {
+		bound(max, "<")
+	}
+	return c
+}
+
+// constraintCacheSize limits the number of constraints memoized by Satisfies.
+const constraintCacheSize = 256
+
+var constraintCache = struct {
+	sync.Mutex
+	m map[string]*Constraint
+}{m: map[string]*Constraint{}}
+
+// cachedConstraint is like NewConstraint but memoizes the parsed constraints. The cache is
+// cleared when it is full. Invalid expressions are not cached.
+func cachedConstraint(expr string) (*Constraint, error) {
+	constraintCache.Lock()
+	defer constraintCache.Unlock()
+	if c, ok := constraintCache.m[expr]; ok {
+		return c, nil
+	}
+	c, err := NewConstraint(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(constraintCache.m) >= constraintCacheSize {
+		clear(constraintCache.m)
+	}
+	constraintCache.m[expr] = c
+	return c, nil
+}
+
+// Satisfies parses the constraint and reports whether v satisfies it, e.g. v.Satisfies(">=1.4.0 <2.0.0").
+// Parsed constraints are memoized, so calling it in a loop with the same expression is cheap.
+// An invalid constraint is reported as error.
+func (v *Version) Satisfies(constraint string) (bool, error) {
+	c, err := cachedConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+	return c.Check(v), nil
+}
+
+// MustSatisfy is like Satisfies but panics if the constraint is invalid.
+func (v *Version) MustSatisfy(constraint string) bool {
+	ok, err := v.Satisfies(constraint)
+	if err != nil {
+		panic(err)
+	}
+	return ok
+}
+
+// ConstraintSatisfiedBy returns the version strings that satisfy the constraint, preserving their order.
+// Version strings that can't be parsed are skipped, an invalid constraint is reported as error.
+func ConstraintSatisfiedBy(constraint string, versions ...string) ([]string, error) {
+	c, err := cachedConstraint(constraint)
+	if err != nil {
+		return nil, err
+	}
+	res := []string{}
+	for _, str := range versions {
+		if v, err := NewFromString(str); err == nil && c.Check(v) {
+			res = append(res, str)
+		}
+	}
+	return res, nil
+}
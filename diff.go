@@ -0,0 +1,88 @@
+package semver
+
+import "fmt"
+
+// DiffLevel is the highest-order component in which two versions differ.
+type DiffLevel int
+
+const (
+	DiffNone DiffLevel = iota
+	DiffMajor
+	DiffMinor
+	DiffPatch
+	DiffPreRelease
+	DiffMetadata
+)
+
+func (l DiffLevel) String() string {
+	switch l {
+	case DiffNone:
+		return "none"
+	case DiffMajor:
+		return "major"
+	case DiffMinor:
+		return "minor"
+	case DiffPatch:
+		return "patch"
+	case DiffPreRelease:
+		return "prerelease"
+	case DiffMetadata:
+		return "metadata"
+	}
+	return "unknown"
+}
+
+// Diff returns the highest-order component in which v and other differ,
+// and the result of comparing v to other (see Compare) to tell the direction.
+func (v *Version) Diff(other *Version) (level DiffLevel, cmp int) {
+	cmp = compare(v, other)
+	switch {
+	case v.major != other.major:
+		return DiffMajor, cmp
+	case v.minor != other.minor:
+		return DiffMinor, cmp
+	case v.patch != other.patch:
+		return DiffPatch, cmp
+	case v.suffix != other.suffix:
+		return DiffPreRelease, cmp
+	case v.metadata != other.metadata:
+		return DiffMetadata, cmp
+	}
+	return DiffNone, cmp
+}
+
+// IsCompatibleWith reports whether v can be used where other is required: the major versions
+// have to match and v's minor and patch must be at least as high as other's. Because 0.x versions
+// make no stability promises, they additionally need matching minor versions.
+// Pre-releases are only compatible with equal versions.
+func (v *Version) IsCompatibleWith(other *Version) bool {
+	if v.suffix != "" || other.suffix != "" {
+		return compare(v, other) == 0
+	}
+	if v.major != other.major {
+		return false
+	}
+	if v.major == 0 && v.minor != other.minor {
+		return false
+	}
+	return compare(v, other) >= 0
+}
+
+// Delta returns the signed difference of each component of other and v, e.g. v1.2.0 to v1.4.5 is 0, 2, 5.
+// The values are plain arithmetic differences, not the number of releases in between: from v1.5.0
+// to v2.2.0 it is 1, -3, 0. If v is ahead of other, the differences are negative.
+func (v *Version) Delta(other *Version) (major, minor, patch int) {
+	return other.major - v.major, other.minor - v.minor, other.patch - v.patch
+}
+
+// DeltaString describes the Delta to other, e.g. "behind by 0.2.5", "ahead by 0.2.5" or "up to date".
+func (v *Version) DeltaString(other *Version) string {
+	major, minor, patch := v.Delta(other)
+	switch c := v.CompareCore(other); {
+	case c < 0:
+		return fmt.Sprintf("behind by %d.%d.%d", major, minor, patch)
+	case c > 0:
+		return fmt.Sprintf("ahead by %d.%d.%d", -major, -minor, -patch)
+	}
+	return "up to date"
+}
@@ -0,0 +1,105 @@
+package semver
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// MarshalXML encodes the version as a single element containing its string form.
+func (v *Version) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(v.String(), start)
+}
+
+// UnmarshalXML decodes a version from the text content of an element.
+func (v *Version) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	version, err := NewFromString(s)
+	if err != nil {
+		return err
+	}
+	*v = *version
+	return nil
+}
+
+// ToMap returns the fields of the version as a map, e.g. for structured logging or templates.
+func (v *Version) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"major":    v.major,
+		"minor":    v.minor,
+		"patch":    v.patch,
+		"suffix":   v.suffix,
+		"metadata": v.metadata,
+		"version":  v.String(),
+	}
+}
+
+func mapInt(m map[string]interface{}, key string) (int, error) {
+	switch n := m[key].(type) {
+	case nil:
+		return 0, nil
+	case int:
+		return n, nil
+	case int64:
+		return int(n), nil
+	case float64:
+		if n != float64(int(n)) {
+			return 0, fmt.Errorf("%s is not an integer: %v", key, n)
+		}
+		return int(n), nil
+	}
+	return 0, fmt.Errorf("%s is not an integer: %v", key, m[key])
+}
+
+func mapString(m map[string]interface{}, key string) (string, error) {
+	switch s := m[key].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return s, nil
+	}
+	return "", fmt.Errorf("%s is not a string: %v", key, m[key])
+}
+
+// NewFromMap creates a version from a map as returned by ToMap. If the map has a "version" key
+// it is parsed, otherwise the version is built from the "major", "minor", "patch", "suffix"
+// and "metadata" keys, of which missing ones are treated as zero or empty.
+func NewFromMap(m map[string]interface{}) (*Version, error) {
+	if _, ok := m["version"]; ok {
+		str, err := mapString(m, "version")
+		if err != nil {
+			return nil, err
+		}
+		return NewFromString(str)
+	}
+	version := New()
+	for _, c := range []struct {
+		key string
+		set func(int) error
+	}{
+		{"major", version.SetMajorE},
+		{"minor", version.SetMinorE},
+		{"patch", version.SetPatchE},
+	} {
+		n, err := mapInt(m, c.key)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.set(n); err != nil {
+			return nil, err
+		}
+	}
+	suffix, err := mapString(m, "suffix")
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := mapString(m, "metadata")
+	if err != nil {
+		return nil, err
+	}
+	version.suffix = suffix
+	version.metadata = metadata
+	return version, nil
+}
@@ -0,0 +1,68 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrEmptyInput is wrapped by errors for input that is empty or only whitespace.
+	ErrEmptyInput = errors.New("empty input")
+	// ErrInvalidFormat is wrapped by errors for input that doesn't have the form of a version.
+	ErrInvalidFormat = errors.New("invalid format")
+	// ErrInvalidNumber is wrapped by errors for version components that can't be represented.
+	ErrInvalidNumber = errors.New("invalid number")
+)
+
+// InvalidVersionError is returned when a string can not be parsed as a version.
+type InvalidVersionError struct {
+	Input     string
+	Reason    string
+	Component string // the offending component ("major", "minor", "patch" or "suffix"), if any
+	Offset    int    // byte offset in Input where parsing failed
+	Err       error  // one of ErrEmptyInput, ErrInvalidFormat or ErrInvalidNumber
+}
+
+// ParseError is the error type returned by all parsing functions.
+type ParseError = InvalidVersionError
+
+func (e *InvalidVersionError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Reason, e.Input)
+}
+
+// Is reports whether target is an *InvalidVersionError whose non-empty fields match e.
+func (e *InvalidVersionError) Is(target error) bool {
+	t, ok := target.(*InvalidVersionError)
+	if !ok {
+		return false
+	}
+	return (t.Input == "" || t.Input == e.Input) && (t.Reason == "" || t.Reason == e.Reason)
+}
+
+func (e *InvalidVersionError) Unwrap() error {
+	return e.Err
+}
+
+// InvalidConstraintError is returned when a constraint expression can not be parsed.
+type InvalidConstraintError struct {
+	Expr   string
+	Reason string
+	Token  string // the offending token, if any
+	Offset int    // byte offset of the offending token in Expr
+}
+
+// ConstraintParseError is an alias for InvalidConstraintError.
+type ConstraintParseError = InvalidConstraintError
+
+func (e *InvalidConstraintError) Error() string {
+	return fmt.Sprintf("%s at offset %d: %s", e.Reason, e.Offset, e.Expr)
+}
+
+// Is reports whether target is an *InvalidConstraintError whose non-empty fields match e.
+func (e *InvalidConstraintError) Is(target error) bool {
+	t, ok := target.(*InvalidConstraintError)
+	if !ok {
+		return false
+	}
+	return (t.Expr == "" || t.Expr == e.Expr) && (t.Reason == "" || t.Reason == e.Reason)
+}
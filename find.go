@@ -0,0 +1,49 @@
+package semver
+
+import "regexp"
+
+var (
+	reSemVerInText = regexp.MustCompile(`[vV]?\d+\.\d+\.\d+(?:-[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?`)
+)
+
+// VersionMatch is a version found in text, with the byte offsets of its start and end.
+type VersionMatch struct {
+	Text  string
+	Start int
+	End   int
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// FindAllVersionStrings returns every major.minor.patch version mentioned in text, in order of appearance.
+// Matches have to stand on their own, so "127.0.0.1" or "x1.2.3" yield nothing.
+func FindAllVersionStrings(text string) []VersionMatch {
+	res := []VersionMatch{}
+	for _, loc := range reSemVerInText.FindAllStringIndex(text, -1) {
+		start, end := loc[0], loc[1]
+		if start > 0 && (isWordByte(text[start-1]) || text[start-1] == '.') {
+			continue
+		}
+		if end < len(text) && (isWordByte(text[end]) || text[end] == '.' && end+1 < len(text) && isWordByte(text[end+1])) {
+			continue
+		}
+		res = append(res, VersionMatch{Text: text[start:end], Start: start, End: end})
+	}
+	return res
+}
+
+// FindAllVersions returns every major.minor.patch version mentioned in text, in order of appearance.
+// Duplicates are kept.
+func FindAllVersions(text string) []*Version {
+	res := []*Version{}
+	for _, m := range FindAllVersionStrings(text) {
+		version, err := NewFromStringWithOptions(m.Text, ParseOptions{AllowLeadingV: true, AllowBuildMeta: true})
+		if err != nil {
+			continue
+		}
+		res = append(res, version)
+	}
+	return res
+}
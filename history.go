@@ -0,0 +1,123 @@
+package semver
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// historyDateLayouts are the date formats accepted by VersionHistoryReader.
+var historyDateLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+
+// VersionHistoryEntry is a line of a release history, see VersionHistoryReader.
+type VersionHistoryEntry struct {
+	Version     *Version
+	Date        time.Time // zero if the line has no date
+	Author      string
+	Description string
+}
+
+// VersionHistoryReader reads release histories with lines of the form
+// "VERSION\tDATE\tAUTHOR\tDESCRIPTION". Fields are separated by a single tab and surrounding
+// whitespace is trimmed. Only the version is required, the other columns may be empty or missing
+// and the description may contain further tabs.
+// The version is parsed without coercion, so lines whose fields are separated by spaces are reported
+// as invalid. Dates are parsed as RFC 3339, "2006-01-02 15:04:05" or "2006-01-02".
+// Blank lines and lines starting with "#" are skipped.
+type VersionHistoryReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func NewVersionHistoryReader(r io.Reader) *VersionHistoryReader {
+	return &VersionHistoryReader{
+		scanner: bufio.NewScanner(r),
+	}
+}
+
+// Read returns the next entry, or io.EOF if there are no more. An invalid line is reported with its
+// line number and skipped, so reading can continue with the next call.
+func (h *VersionHistoryReader) Read() (VersionHistoryEntry, error) {
+	for h.scanner.Scan() {
+		h.line++
+		line := strings.TrimSpace(h.scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entry, err := parseHistoryLine(line)
+		if err != nil {
+			return VersionHistoryEntry{}, fmt.Errorf("line %d (%q): %w", h.line, line, err)
+		}
+		return entry, nil
+	}
+	if err := h.scanner.Err(); err != nil {
+		return VersionHistoryEntry{}, err
+	}
+	return VersionHistoryEntry{}, io.EOF
+}
+
+// ReadAll reads all remaining entries. Invalid lines are collected in the returned error,
+// the valid entries are returned in input order.
+func (h *VersionHistoryReader) ReadAll() ([]VersionHistoryEntry, error) {
+	res := []VersionHistoryEntry{}
+	errs := []error{}
+	for {
+		entry, err := h.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errs = append(errs, err)
+			if h.scanner.Err() != nil {
+				break
+			}
+			continue
+		}
+		res = append(res, entry)
+	}
+	return res, errors.Join(errs...)
+}
+
+func parseHistoryLine(line string) (VersionHistoryEntry, error) {
+	fields := splitHistoryFields(line, 4)
+	entry := VersionHistoryEntry{}
+	version, err := NewFromStringWithOptions(fields[0], ParseOptions{AllowLeadingV: true, AllowBuildMeta: true})
+	if err != nil {
+		return entry, err
+	}
+	entry.Version = version
+	if len(fields) > 1 && fields[1] != "" {
+		if entry.Date, err = parseHistoryDate(fields[1]); err != nil {
+			return entry, err
+		}
+	}
+	if len(fields) > 2 {
+		entry.Author = fields[2]
+	}
+	if len(fields) > 3 {
+		entry.Description = fields[3]
+	}
+	return entry, nil
+}
+
+// splitHistoryFields splits line at single tabs into at most n trimmed fields,
+// so empty columns are kept and the last field keeps any further tabs.
+func splitHistoryFields(line string, n int) []string {
+	fields := strings.SplitN(line, "\t", n)
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	return fields
+}
+
+func parseHistoryDate(str string) (time.Time, error) {
+	for _, layout := range historyDateLayouts {
+		if t, err := time.Parse(layout, str); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", str)
+}
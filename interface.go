@@ -0,0 +1,234 @@
+package semver
+
+import "sort"
+
+// SemVer is implemented by *Version. It allows callers to supply their own version types,
+// e.g. fakes in tests, to the functions with an I suffix.
+type SemVer interface {
+	GetMajor() int
+	GetMinor() int
+	GetPatch() int
+	GetSuffix() string
+	String() string
+	Compare(other SemVer) int
+	IsPreRelease() bool
+}
+
+// CompareI is like Version.Compare for any SemVer implementations.
+func CompareI(a, b SemVer) int {
+	if a.GetMajor() != b.GetMajor() {
+		return cmpInt(a.GetMajor(), b.GetMajor())
+	}
+	if a.GetMinor() != b.GetMinor() {
+		return cmpInt(a.GetMinor(), b.GetMinor())
+	}
+	if a.GetPatch() != b.GetPatch() {
+		return cmpInt(a.GetPatch(), b.GetPatch())
+	}
+	return comparePreRelease(a.GetSuffix(), b.GetSuffix())
+}
+
+// EqualI is like Version.Equal for any SemVer implementations.
+func EqualI(a, b SemVer) bool {
+	return CompareI(a, b) == 0
+}
+
+// SortVersionsI is like SortVersions for any SemVer implementations.
+func SortVersionsI(versions []SemVer) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return CompareI(versions[i], versions[j]) < 0
+	})
+}
+
+// MaxI is like Max for any SemVer implementations.
+func MaxI(versions []SemVer) SemVer {
+	var res SemVer
+	for _, v := range versions {
+		if v != nil && (res == nil || CompareI(v, res) > 0) {
+			res = v
+		}
+	}
+	return res
+}
+
+// SortedVersionsI is like SortedVersions for any SemVer implementations.
+func SortedVersionsI(versions []SemVer) []SemVer {
+	sorted := make([]SemVer, len(versions))
+	copy(sorted, versions)
+	SortVersionsI(sorted)
+	return sorted
+}
+
+// ReverseSortedVersionsI is like ReverseSortedVersions for any SemVer implementations.
+func ReverseSortedVersionsI(versions []SemVer) []SemVer {
+	sorted := make([]SemVer, len(versions))
+	copy(sorted, versions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return CompareI(sorted[i], sorted[j]) > 0
+	})
+	return sorted
+}
+
+// UniqueI is like Unique for any SemVer implementations.
+func UniqueI(versions []SemVer) []SemVer {
+	seen := map[versionKey]bool{}
+	res := []SemVer{}
+	for _, v := range versions {
+		if v == nil {
+			continue
+		}
+		k := versionKey{v.GetMajor(), v.GetMinor(), v.GetPatch(), v.GetSuffix()}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		res = append(res, v)
+	}
+	return res
+}
+
+// IndexI is like Index for any SemVer implementations.
+func IndexI(versions []SemVer, target SemVer) int {
+	if target == nil {
+		return -1
+	}
+	for i, v := range versions {
+		if v != nil && CompareI(v, target) == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// ContainsI is like Contains for any SemVer implementations.
+func ContainsI(versions []SemVer, target SemVer) bool {
+	return IndexI(versions, target) >= 0
+}
+
+// SearchI is like Search for any SemVer implementations.
+func SearchI(versions []SemVer, target SemVer) (index int, found bool) {
+	i := sort.Search(len(versions), func(i int) bool {
+		return CompareI(versions[i], target) >= 0
+	})
+	return i, i < len(versions) && CompareI(versions[i], target) == 0
+}
+
+// NearestBelowI is like NearestBelow for any SemVer implementations.
+func NearestBelowI(versions []SemVer, target SemVer) SemVer {
+	i := sort.Search(len(versions), func(i int) bool {
+		return CompareI(versions[i], target) > 0
+	})
+	if i == 0 {
+		return nil
+	}
+	return versions[i-1]
+}
+
+// NearestAboveI is like NearestAbove for any SemVer implementations.
+func NearestAboveI(versions []SemVer, target SemVer) SemVer {
+	i, _ := SearchI(versions, target)
+	if i == len(versions) {
+		return nil
+	}
+	return versions[i]
+}
+
+// InsertSortedI is like InsertSorted for any SemVer implementations.
+func InsertSortedI(versions []SemVer, v SemVer) []SemVer {
+	i := sort.Search(len(versions), func(i int) bool {
+		return CompareI(versions[i], v) > 0
+	})
+	versions = append(versions, nil)
+	copy(versions[i+1:], versions[i:])
+	versions[i] = v
+	return versions
+}
+
+func filterI(versions []SemVer, keep func(v SemVer) bool) []SemVer {
+	res := []SemVer{}
+	for _, v := range versions {
+		if v != nil && keep(v) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// FilterStableI is like FilterStable for any SemVer implementations.
+func FilterStableI(versions []SemVer) []SemVer {
+	return filterI(versions, func(v SemVer) bool { return !v.IsPreRelease() })
+}
+
+// FilterPrereleaseI is like FilterPrerelease for any SemVer implementations.
+func FilterPrereleaseI(versions []SemVer) []SemVer {
+	return filterI(versions, SemVer.IsPreRelease)
+}
+
+// GroupByMajorI is like GroupByMajor for any SemVer implementations.
+func GroupByMajorI(versions []SemVer) map[int][]SemVer {
+	groups := map[int][]SemVer{}
+	for _, v := range versions {
+		if v == nil {
+			continue
+		}
+		groups[v.GetMajor()] = append(groups[v.GetMajor()], v)
+	}
+	for _, group := range groups {
+		SortVersionsI(group)
+	}
+	return groups
+}
+
+// LatestPerMinorI is like LatestPerMinor for any SemVer implementations.
+func LatestPerMinorI(versions []SemVer, includePreRelease bool) []SemVer {
+	sorted := filterI(versions, func(v SemVer) bool { return includePreRelease || !v.IsPreRelease() })
+	SortVersionsI(sorted)
+	res := []SemVer{}
+	for i, v := range sorted {
+		if i+1 < len(sorted) && sorted[i+1].GetMajor() == v.GetMajor() && sorted[i+1].GetMinor() == v.GetMinor() {
+			continue
+		}
+		res = append(res, v)
+	}
+	return res
+}
+
+// ChangelogI is like Changelog for any SemVer implementations.
+func ChangelogI(from, to SemVer, versions []SemVer) []SemVer {
+	return BetweenI(versions, from, to, false, true)
+}
+
+// BetweenI is like Between for any SemVer implementations.
+func BetweenI(versions []SemVer, lo, hi SemVer, includeLo, includeHi bool) []SemVer {
+	res := filterI(versions, func(v SemVer) bool {
+		if lo != nil {
+			if c := CompareI(v, lo); c < 0 || (c == 0 && !includeLo) {
+				return false
+			}
+		}
+		if hi != nil {
+			if c := CompareI(v, hi); c > 0 || (c == 0 && !includeHi) {
+				return false
+			}
+		}
+		return true
+	})
+	SortVersionsI(res)
+	return res
+}
+
+// NegotiateI is like Negotiate for any SemVer implementations; the returned version is taken from ours.
+func NegotiateI(ours, theirs []SemVer) SemVer {
+	return MaxI(filterI(ours, func(v SemVer) bool { return ContainsI(theirs, v) }))
+}
+
+// VersionStringsI is like VersionStrings for any SemVer implementations.
+func VersionStringsI(versions []SemVer) []string {
+	res := []string{}
+	for _, v := range versions {
+		if v != nil {
+			res = append(res, v.String())
+		}
+	}
+	return res
+}
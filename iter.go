@@ -0,0 +1,90 @@
+//go:build go1.23
+
+package semver
+
+import (
+	"container/heap"
+	"iter"
+)
+
+// versionHeap is a heap of indices into versions, ordered by less and then by index,
+// so equal versions are yielded in their original order.
+type versionHeap struct {
+	versions []*Version
+	indices  []int
+	less     func(a, b *Version) bool
+}
+
+func (h *versionHeap) Len() int {
+	return len(h.indices)
+}
+
+func (h *versionHeap) Less(i, j int) bool {
+	a, b := h.versions[h.indices[i]], h.versions[h.indices[j]]
+	if h.less(a, b) {
+		return true
+	}
+	if h.less(b, a) {
+		return false
+	}
+	return h.indices[i] < h.indices[j]
+}
+
+func (h *versionHeap) Swap(i, j int) {
+	h.indices[i], h.indices[j] = h.indices[j], h.indices[i]
+}
+
+func (h *versionHeap) Push(x any) {
+	h.indices = append(h.indices, x.(int))
+}
+
+func (h *versionHeap) Pop() any {
+	i := h.indices[len(h.indices)-1]
+	h.indices = h.indices[:len(h.indices)-1]
+	return i
+}
+
+// ordered yields the non-nil versions ordered by less. Each version is only
+// taken from the heap when it is requested, so breaking early skips sorting the rest.
+func ordered(versions []*Version, less func(a, b *Version) bool) iter.Seq[*Version] {
+	return func(yield func(*Version) bool) {
+		h := &versionHeap{
+			versions: versions,
+			indices:  make([]int, 0, len(versions)),
+			less:     less,
+		}
+		for i, v := range versions {
+			if v != nil {
+				h.indices = append(h.indices, i)
+			}
+		}
+		heap.Init(h)
+		for h.Len() > 0 {
+			if !yield(versions[heap.Pop(h).(int)]) {
+				return
+			}
+		}
+	}
+}
+
+// Ascending returns an iterator over the versions from oldest to newest, like SortVersions
+// would order them. The slice is neither modified nor copied, nil entries are skipped.
+func Ascending(versions []*Version) iter.Seq[*Version] {
+	return ordered(versions, func(a, b *Version) bool { return compare(a, b) < 0 })
+}
+
+// Descending is like Ascending but yields the versions from newest to oldest.
+func Descending(versions []*Version) iter.Seq[*Version] {
+	return ordered(versions, func(a, b *Version) bool { return compare(a, b) > 0 })
+}
+
+// All returns an iterator over the versions of the set from oldest to newest.
+func (s *VersionSet) All() iter.Seq[*Version] {
+	return func(yield func(*Version) bool) {
+		for _, v := range s.ToSlice() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
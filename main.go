@@ -1,51 +1,311 @@
 package semver
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
-	"regexp"
+	"hash/fnv"
+	"math"
 	"sort"
 	"strconv"
 	"strings"
 )
 
-var (
-	reSemVer = regexp.MustCompile(`(?:v|V|)((?:\d+\.){0,2}\d+)-{0,1}(.*)`)
-)
+// MaxComponent is the largest value accepted for the major, minor and patch components.
+// It is 2^53-1, so components survive a round-trip through JSON numbers, or math.MaxInt32
+// on platforms where int is 32 bits wide.
+const MaxComponent = min(1<<53-1, math.MaxInt)
+
+func checkComponent(name string, n int) error {
+	if n < 0 || n > MaxComponent {
+		return fmt.Errorf("%s version %d out of range 0 to %d: %w", name, n, MaxComponent, ErrInvalidNumber)
+	}
+	return nil
+}
 
 type Version struct {
-	major  int
-	minor  int
-	patch  int
-	suffix string
+	major     int
+	minor     int
+	patch     int
+	suffix    string
+	metadata  string
+	precision int // number of components to render, 0 renders as few as needed
+	err       error
 }
 
-func (v *Version) SetMajor(version int) *Version {
-	v.major = version
+// setComponent clamps n to the range 0 to MaxComponent and records an error if it had to.
+func (v *Version) setComponent(name string, field *int, n int) *Version {
+	if err := checkComponent(name, n); err != nil {
+		v.err = errors.Join(v.err, err)
+	}
+	*field = max(0, min(n, MaxComponent))
 	return v
 }
 
+// SetMajor sets the major version. Values outside the range 0 to MaxComponent are clamped
+// and the problem is recorded, see Err. The same applies to SetMinor, SetPatch and Set.
+func (v *Version) SetMajor(version int) *Version {
+	return v.setComponent("major", &v.major, version)
+}
+
+// SetMinor sets the minor version and raises the precision to at least 2,
+// so an explicitly set minor version is rendered by String even if it is 0.
 func (v *Version) SetMinor(version int) *Version {
-	v.minor = version
-	return v
+	v.precision = max(v.precision, 2)
+	return v.setComponent("minor", &v.minor, version)
 }
 
+// SetPatch sets the patch version and raises the precision to 3, see SetMinor.
 func (v *Version) SetPatch(version int) *Version {
+	v.precision = 3
+	return v.setComponent("patch", &v.patch, version)
+}
+
+// SetMajorE sets the major version, or returns an error and leaves the version unchanged
+// if the value is outside the range 0 to MaxComponent.
+func (v *Version) SetMajorE(version int) error {
+	if err := checkComponent("major", version); err != nil {
+		return err
+	}
+	v.major = version
+	return nil
+}
+
+// SetMinorE is like SetMajorE for the minor version. Like SetMinor it raises the precision to at least 2.
+func (v *Version) SetMinorE(version int) error {
+	if err := checkComponent("minor", version); err != nil {
+		return err
+	}
+	v.minor = version
+	v.precision = max(v.precision, 2)
+	return nil
+}
+
+// SetPatchE is like SetMajorE for the patch version. Like SetPatch it raises the precision to 3.
+func (v *Version) SetPatchE(version int) error {
+	if err := checkComponent("patch", version); err != nil {
+		return err
+	}
 	v.patch = version
+	v.precision = 3
+	return nil
+}
+
+// Err returns the problems recorded by the chainable setters, or nil if there were none.
+func (v *Version) Err() error {
+	return v.err
+}
+
+// SetPrecision sets the number of components (1 to 3) that String renders, e.g. "v1", "v1.0" or "v1.0.0".
+// Non-zero components are always rendered. A precision of 0 renders as few components as needed.
+func (v *Version) SetPrecision(n int) *Version {
+	v.precision = max(0, min(n, 3))
 	return v
 }
 
+// GetPrecision returns the precision set by SetPrecision or the number of components the version was parsed from.
+func (v *Version) GetPrecision() int {
+	return v.precision
+}
+
+func (v *Version) GetMajor() int {
+	return v.major
+}
+
+func (v *Version) GetMinor() int {
+	return v.minor
+}
+
+func (v *Version) GetPatch() int {
+	return v.patch
+}
+
+func (v *Version) GetSuffix() string {
+	return v.suffix
+}
+
+func (v *Version) GetMetadata() string {
+	return v.metadata
+}
+
+// SetSuffix sets the pre-release suffix from its elements. Elements containing dots are split
+// into several identifiers and empty identifiers are dropped, so SetSuffix("rc.1"), SetSuffix("rc", "1")
+// and SetSuffix("rc", "", ".1") all set "rc.1" and no elements clear the suffix. Identifiers that
+// violate the SemVer spec are kept but the problem is recorded, see Err and SetSuffixE.
 func (v *Version) SetSuffix(elements ...string) *Version {
-	v.suffix = ""
-	if len(elements) > 0 {
-		v.suffix = strings.Join(elements, ".")
+	v.suffix = joinSuffix(elements)
+	if err := ValidateSuffix(v.suffix); err != nil {
+		v.err = errors.Join(v.err, err)
 	}
 	return v
 }
 
+// SetSuffixE is like SetSuffix but returns an error and leaves the version unchanged
+// if the resulting suffix violates the SemVer spec.
+func (v *Version) SetSuffixE(elements ...string) error {
+	suffix := joinSuffix(elements)
+	if err := ValidateSuffix(suffix); err != nil {
+		return err
+	}
+	v.suffix = suffix
+	return nil
+}
+
+// joinSuffix joins the elements with dots, dropping empty identifiers.
+func joinSuffix(elements []string) string {
+	ids := []string{}
+	for _, e := range elements {
+		for _, id := range strings.Split(e, ".") {
+			if id != "" {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return strings.Join(ids, ".")
+}
+
 func (v *Version) Set(major, minor, patch int, suffixes ...string) *Version {
 	return v.SetMajor(major).SetMinor(minor).SetPatch(patch).SetSuffix(suffixes...)
 }
 
+// Add adds the (possibly negative) deltas to the components without carrying, so v1.2.9 plus 0.0.1 is v1.2.10.
+// The suffix and precision are left untouched. Components that would go below 0 are handled like SetMajor does.
+func (v *Version) Add(major, minor, patch int) *Version {
+	v.setComponent("major", &v.major, v.major+major)
+	v.setComponent("minor", &v.minor, v.minor+minor)
+	return v.setComponent("patch", &v.patch, v.patch+patch)
+}
+
+func (v *Version) clone() *Version {
+	c := *v
+	return &c
+}
+
+// Clone returns a copy of the version that can be modified independently. A nil version is returned as nil.
+func (v *Version) Clone() *Version {
+	if v == nil {
+		return nil
+	}
+	return v.clone()
+}
+
+// ReleaseVersion returns a copy of the version without its suffix.
+// This is the canonical way to promote a pre-release, e.g. v1.2.3-rc.1, to its final release v1.2.3.
+func (v *Version) ReleaseVersion() *Version {
+	return v.clone().SetSuffix()
+}
+
+// Core returns a copy of the version with only its major, minor and patch components.
+func (v *Version) Core() *Version {
+	return NewFromInts(v.major, v.minor, v.patch)
+}
+
+// CoreString returns the full three-component string of the core version, e.g. "v2.1.0" for v2.1.0-rc.1.
+func (v *Version) CoreString() string {
+	return v.Core().LongString()
+}
+
+// Truncate returns a copy of the version reduced to the given number of components (1 to 3),
+// with the lower components zeroed and without suffix, e.g. v1.4.7-rc.2 truncated to 2 is v1.4.0.
+func (v *Version) Truncate(precision int) (*Version, error) {
+	switch precision {
+	case 1:
+		return NewFromInts(v.major, 0, 0), nil
+	case 2:
+		return NewFromInts(v.major, v.minor, 0), nil
+	case 3:
+		return NewFromInts(v.major, v.minor, v.patch), nil
+	}
+	return nil, fmt.Errorf("invalid precision %d, must be 1, 2 or 3", precision)
+}
+
+// TruncateTo returns a copy of the version that keeps everything up to the named component
+// ("major", "minor", "patch" or "suffix") and zeroes or clears the rest, e.g. v2.3.4-rc.1
+// truncated to "minor" is v2.3.0 and truncated to "suffix" is v2.3.4-rc.1 without build metadata.
+func (v *Version) TruncateTo(component string) (*Version, error) {
+	switch component {
+	case "major":
+		return v.Truncate(1)
+	case "minor":
+		return v.Truncate(2)
+	case "patch":
+		return v.Truncate(3)
+	case "suffix":
+		c := v.Core()
+		c.suffix = v.suffix
+		return c, nil
+	}
+	return nil, fmt.Errorf("invalid component %q, must be major, minor, patch or suffix", component)
+}
+
+// RoundUp returns a copy of the version with the named component ("major", "minor" or "patch")
+// incremented and everything below it zeroed or cleared, e.g. v2.3.4 rounded up to "minor" is v2.4.0.
+// It is the exclusive upper bound counterpart of TruncateTo.
+func (v *Version) RoundUp(component string) (*Version, error) {
+	switch component {
+	case "major":
+		return NewFromInts(v.major+1, 0, 0), nil
+	case "minor":
+		return NewFromInts(v.major, v.minor+1, 0), nil
+	case "patch":
+		return NewFromInts(v.major, v.minor, v.patch+1), nil
+	}
+	return nil, fmt.Errorf("invalid component %q, must be major, minor or patch", component)
+}
+
+// TruncatedString returns the truncated version with exactly the given number of components, e.g. "v1.4".
+func (v *Version) TruncatedString(precision int) (string, error) {
+	t, err := v.Truncate(precision)
+	if err != nil {
+		return "", err
+	}
+	components := []string{strconv.Itoa(t.major), strconv.Itoa(t.minor), strconv.Itoa(t.patch)}
+	return "v" + strings.Join(components[:precision], "."), nil
+}
+
+// ToMajor returns a copy of the version with only the major component, e.g. v1.0.0 for v1.4.7.
+func (v *Version) ToMajor() *Version {
+	t, _ := v.Truncate(1)
+	return t
+}
+
+// ToMinor returns a copy of the version with only the major and minor components, e.g. v1.4.0 for v1.4.7.
+func (v *Version) ToMinor() *Version {
+	t, _ := v.Truncate(2)
+	return t
+}
+
+// ToInts returns the major, minor and patch components.
+func (v *Version) ToInts() (major, minor, patch int) {
+	return v.major, v.minor, v.patch
+}
+
+// IsPreRelease reports whether the version has a suffix. Build metadata alone doesn't make a pre-release.
+func (v *Version) IsPreRelease() bool {
+	return v.suffix != ""
+}
+
+// IsStable reports whether the version has no suffix.
+func (v *Version) IsStable() bool {
+	return !v.IsPreRelease()
+}
+
+// IsIncompatible reports whether the version carries the "+incompatible" build metadata
+// that Go uses for modules with a major version of 2 or higher that don't have a go.mod file.
+func (v *Version) IsIncompatible() bool {
+	return v.metadata == "incompatible"
+}
+
+// IsDevelopment reports whether the major version is 0, which by convention makes no stability promises.
+func (v *Version) IsDevelopment() bool {
+	return v.major == 0
+}
+
+// IsZero reports whether the version is 0.0.0 without suffix or metadata, e.g. because it was never set.
+func (v *Version) IsZero() bool {
+	return v.major == 0 && v.minor == 0 && v.patch == 0 && v.suffix == "" && v.metadata == ""
+}
+
 func (v *Version) SetFromString(str string) *Version {
 	version, err := NewFromString(str)
 	if err != nil {
@@ -55,88 +315,332 @@ func (v *Version) SetFromString(str string) *Version {
 }
 
 func (v *Version) String() string {
+	return v.StringWithOptions(FormatOptions{})
+}
+
+// GoString implements fmt.GoStringer, so %#v prints Go code that reconstructs the version,
+// e.g. semver.MustParse("v1.2.3-rc.1+build.5").
+func (v *Version) GoString() string {
+	if v == nil {
+		return "(*semver.Version)(nil)"
+	}
+	return fmt.Sprintf("semver.MustParse(%q)", v.String())
+}
+
+// LongString returns the string representation with all three components, e.g. "v1.0.0" instead of "v1".
+func (v *Version) LongString() string {
+	return v.StringWithOptions(FormatOptions{AllComponents: true})
+}
+
+// DecimalVersion returns the version as major + minor/1000 + patch/1000000.
+// The encoding is lossy (it ignores the suffix and breaks down for minor or patch versions
+// of 1000 or more) and only meant for display or for approximate comparisons with systems
+// that represent versions as numbers.
+func (v *Version) DecimalVersion() float64 {
+	return float64(v.major) + float64(v.minor)/1000.0 + float64(v.patch)/1000000.0
+}
+
+// Key returns a canonical string for use as map key or cache identifier, e.g. "1.2.0-rc.1".
+// It always has all three components, no "v" prefix and no build metadata, regardless of how the
+// version was constructed. Unlike String, its format is guaranteed to stay the same across releases.
+func (v *Version) Key() string {
+	s := strconv.Itoa(v.major) + "." + strconv.Itoa(v.minor) + "." + strconv.Itoa(v.patch)
+	if v.suffix != "" {
+		s += "-" + v.suffix
+	}
+	return s
+}
+
+// Hash returns a 64-bit FNV-1a hash of the version's LongString representation without build metadata,
+// so equal versions have equal hashes.
+func (v *Version) Hash() uint64 {
+	c := v.clone()
+	c.metadata = ""
+	h := fnv.New64a()
+	h.Write([]byte(c.LongString()))
+	return h.Sum64()
+}
+
+// FormatOptions controls how StringWithOptions renders a version.
+type FormatOptions struct {
+	// OmitPrefix leaves out the leading "v".
+	OmitPrefix bool
+	// AllComponents always renders major, minor and patch, even if they are zero.
+	AllComponents bool
+}
+
+func (v *Version) StringWithOptions(opts FormatOptions) string {
+	precision := v.precision
+	if opts.AllComponents {
+		precision = 3
+	} else if v.patch != 0 {
+		precision = 3
+	} else if v.minor != 0 {
+		precision = max(precision, 2)
+	}
 	var s string
-	if v.minor == 0 && v.patch == 0 { // only major set
-		s = fmt.Sprintf("v%d", v.major)
-	} else if v.patch == 0 { // major and minor set
-		s = fmt.Sprintf("v%d.%d", v.major, v.minor)
+	if precision <= 1 { // only major set
+		s = fmt.Sprintf("%d", v.major)
+	} else if precision == 2 { // major and minor set
+		s = fmt.Sprintf("%d.%d", v.major, v.minor)
 	} else { // all components set
-		s = fmt.Sprintf("v%d.%d.%d", v.major, v.minor, v.patch)
+		s = fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
+	}
+	if !opts.OmitPrefix {
+		s = "v" + s
 	}
 	if v.suffix != "" {
 		s += "-" + v.suffix
 	}
+	if v.metadata != "" {
+		s += "+" + v.metadata
+	}
 	return s
 }
 
 func New() *Version {
 	v := &Version{
-		major:  0,
-		minor:  0,
-		patch:  0,
-		suffix: "",
+		major:    0,
+		minor:    0,
+		patch:    0,
+		suffix:   "",
+		metadata: "",
 	}
 	return v
 }
 
+// NewFromInts returns a version with the given components.
+func NewFromInts(major, minor, patch int) *Version {
+	return New().Set(major, minor, patch)
+}
+
+// NewFromIntsWithSuffix returns a version with the given components and suffix.
+func NewFromIntsWithSuffix(major, minor, patch int, suffix string) *Version {
+	return New().Set(major, minor, patch, suffix)
+}
+
 func NewFromString(str string) (*Version, error) {
+	return Parse(str)
+}
+
+// ParseBytes works like NewFromString but takes a byte slice, which is parsed without converting it to a string first.
+func ParseBytes(b []byte) (*Version, error) {
+	return parse(b)
+}
+
+func parse[T byteString](str T) (*Version, error) {
 	version := &Version{
-		major:  0,
-		minor:  0,
-		patch:  0,
-		suffix: "",
+		major:    0,
+		minor:    0,
+		patch:    0,
+		suffix:   "",
+		metadata: "",
 	}
 
-	matches := reSemVer.FindStringSubmatch(str)
-
-	if len(matches) != 3 {
-		return version, fmt.Errorf("invalid version format: %s", str)
+	res, ok := scanVersion(str)
+	if !ok {
+		if len(bytes.TrimSpace([]byte(str))) == 0 {
+			return version, &InvalidVersionError{Input: string(str), Reason: "empty version", Err: ErrEmptyInput}
+		}
+		return version, &InvalidVersionError{Input: string(str), Reason: "invalid version format", Err: ErrInvalidFormat}
 	}
 
 	// Parse version numbers
-	versionNumbers := strings.Split(matches[1], ".")
-	if len(versionNumbers) < 1 || len(versionNumbers) > 3 {
-		return version, fmt.Errorf("invalid version format: %s", str)
-	}
-	major, err := strconv.Atoi(versionNumbers[0])
-	if err != nil {
-		return version, fmt.Errorf("invalid major version: %s", versionNumbers[0])
+	major, ok := atoi(res.components[0])
+	if !ok {
+		return version, &InvalidVersionError{Input: string(str), Reason: "major version exceeds MaxComponent", Component: "major", Offset: res.offsets[0], Err: ErrInvalidNumber}
 	}
 	version.SetMajor(major)
-	if len(versionNumbers) >= 2 {
-		minor, err := strconv.Atoi(versionNumbers[1])
-		if err != nil {
-			return version, fmt.Errorf("invalid minor version: %s", versionNumbers[1])
+	if res.count >= 2 {
+		minor, ok := atoi(res.components[1])
+		if !ok {
+			return version, &InvalidVersionError{Input: string(str), Reason: "minor version exceeds MaxComponent", Component: "minor", Offset: res.offsets[1], Err: ErrInvalidNumber}
 		}
-		version.SetMinor(minor)
+		version.setComponent("minor", &version.minor, minor)
 	}
-	if len(versionNumbers) == 3 {
-		patch, err := strconv.Atoi(versionNumbers[2])
-		if err != nil {
-			return version, fmt.Errorf("invalid patch version: %s", versionNumbers[2])
+	if res.count == 3 {
+		patch, ok := atoi(res.components[2])
+		if !ok {
+			return version, &InvalidVersionError{Input: string(str), Reason: "patch version exceeds MaxComponent", Component: "patch", Offset: res.offsets[2], Err: ErrInvalidNumber}
 		}
-		version.SetPatch(patch)
+		version.setComponent("patch", &version.patch, patch)
 	}
 
-	version.SetSuffix(matches[2])
+	suffix := res.suffix
+	for i := 0; i < len(suffix); i++ {
+		if suffix[i] == '+' {
+			version.metadata = string(suffix[i+1:])
+			suffix = suffix[:i]
+			break
+		}
+	}
+	version.suffix = string(suffix)
+	version.precision = res.count
 
 	return version, nil
 }
 
+// NewFromStringStrict works like NewFromString but requires the major, minor and patch
+// components to be present and the whole string to be a version, e.g. "v1.2", "v1.2.3.4"
+// and "release 1.2.3" are rejected.
+func NewFromStringStrict(str string) (*Version, error) {
+	opts := defaultParseOptions()
+	opts.RequireAllComponents = true
+	opts.AllowCoercion = false
+	return NewFromStringWithOptions(str, opts)
+}
+
+// MustParse is like NewFromString but panics if str can't be parsed.
+// It simplifies the initialization of variables holding known versions.
+func MustParse(str string) *Version {
+	v, err := NewFromString(str)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func compare(a, b *Version) int {
+	return CompareRaw(a.major, a.minor, a.patch, a.suffix, b.major, b.minor, b.patch, b.suffix)
+}
+
+// CompareRaw compares two versions given as raw components and suffixes like Compare does,
+// for callers that keep versions in their own data structures. It does not allocate.
+func CompareRaw(aMajor, aMinor, aPatch int, aSuffix string, bMajor, bMinor, bPatch int, bSuffix string) int {
+	if aMajor != bMajor {
+		return cmpInt(aMajor, bMajor)
+	}
+	if aMinor != bMinor {
+		return cmpInt(aMinor, bMinor)
+	}
+	if aPatch != bPatch {
+		return cmpInt(aPatch, bPatch)
+	}
+	return comparePreRelease(aSuffix, bSuffix)
+}
+
+// Compare returns -1, 0 or 1 depending on whether v is less than, equal to or greater than other.
+// Build metadata is ignored. It does not allocate.
+func (v *Version) Compare(other SemVer) int {
+	if o, ok := other.(*Version); ok {
+		return compare(v, o)
+	}
+	return CompareI(v, other)
+}
+
+// Equal reports whether v and other are semantically equal. Like Compare it ignores build metadata,
+// so v1.0.0+build.1 equals v1.0.0+build.2 and v1.0.0.
+func (v *Version) Equal(other *Version) bool {
+	return compare(v, other) == 0
+}
+
+// GreaterThan reports whether v has a higher precedence than other.
+func (v *Version) GreaterThan(other *Version) bool {
+	return compare(v, other) > 0
+}
+
+// LessThan reports whether v has a lower precedence than other.
+func (v *Version) LessThan(other *Version) bool {
+	return compare(v, other) < 0
+}
+
+// After is a synonym for GreaterThan, named like time.Time.After.
+func (v *Version) After(other *Version) bool {
+	return v.GreaterThan(other)
+}
+
+// Before is a synonym for LessThan, named like time.Time.Before.
+func (v *Version) Before(other *Version) bool {
+	return v.LessThan(other)
+}
+
+// StrictEqual is like Equal but also requires the build metadata to match.
+func (v *Version) StrictEqual(other *Version) bool {
+	return v.Equal(other) && v.metadata == other.metadata
+}
+
+// CompareCore is like Compare but only considers the major, minor and patch components,
+// so v1.2.3-rc.1 and v1.2.3 compare as equal.
+func (v *Version) CompareCore(other *Version) int {
+	if v.major != other.major {
+		return cmpInt(v.major, other.major)
+	}
+	if v.minor != other.minor {
+		return cmpInt(v.minor, other.minor)
+	}
+	return cmpInt(v.patch, other.patch)
+}
+
+// EqualCore reports whether v and other have the same major, minor and patch components.
+func (v *Version) EqualCore(other *Version) bool {
+	return v.CompareCore(other) == 0
+}
+
+func cmpInt(a, b int) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+// Clamp returns v if it lies within [min, max], otherwise the nearer bound. A nil bound leaves that side
+// unbounded. If min is greater than max, the bounds are swapped.
+func (v *Version) Clamp(min, max *Version) *Version {
+	if min != nil && max != nil && compare(min, max) > 0 {
+		min, max = max, min
+	}
+	if min != nil && compare(v, min) < 0 {
+		return min
+	}
+	if max != nil && compare(v, max) > 0 {
+		return max
+	}
+	return v
+}
+
+// Merge returns a new version with the highest major, minor and patch components of a and b,
+// e.g. v1.5.0 and v1.3.2 merge to v1.5.2. The suffix is taken from the version with the higher
+// core, or dropped if both have the same core but different suffixes. Build metadata is dropped.
+func Merge(a, b *Version) *Version {
+	res := NewFromInts(max(a.major, b.major), max(a.minor, b.minor), max(a.patch, b.patch))
+	switch c := a.CompareCore(b); {
+	case c > 0:
+		res.suffix = a.suffix
+	case c < 0:
+		res.suffix = b.suffix
+	case a.suffix == b.suffix:
+		res.suffix = a.suffix
+	}
+	return res
+}
+
 // SortVersions sorts a slice of parsed semantic versions.
+// The sort is stable, so versions that only differ in build metadata keep their order.
 func SortVersions(versions []*Version) {
-	sort.Slice(versions, func(i, j int) bool {
-		if versions[i].major != versions[j].major {
-			return versions[i].major < versions[j].major
-		}
-		if versions[i].minor != versions[j].minor {
-			return versions[i].minor < versions[j].minor
-		}
-		if versions[i].patch != versions[j].patch {
-			return versions[i].patch < versions[j].patch
-		}
-		return versions[i].suffix < versions[j].suffix
+	sort.SliceStable(versions, func(i, j int) bool {
+		return compare(versions[i], versions[j]) < 0
+	})
+}
+
+// SortedVersions returns a sorted copy of the slice, leaving the input unchanged.
+func SortedVersions(versions []*Version) []*Version {
+	sorted := make([]*Version, len(versions))
+	copy(sorted, versions)
+	SortVersions(sorted)
+	return sorted
+}
+
+// ReverseSortedVersions returns a copy of the slice sorted from newest to oldest, leaving the input unchanged.
+func ReverseSortedVersions(versions []*Version) []*Version {
+	sorted := make([]*Version, len(versions))
+	copy(sorted, versions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return compare(sorted[i], sorted[j]) > 0
 	})
+	return sorted
 }
 
 func IsValid(version string) bool {
@@ -0,0 +1,85 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// VersionConstraintMatcher checks several named dependencies against their own constraints,
+// like the "engines" field of a package.json, e.g. {"go": ">=1.21", "node": ">=18"}.
+type VersionConstraintMatcher struct {
+	constraints map[string]*Constraint
+}
+
+// NewVersionConstraintMatcher parses the constraint of each named dependency.
+// All invalid constraints are reported in the returned error.
+func NewVersionConstraintMatcher(constraints map[string]string) (*VersionConstraintMatcher, error) {
+	m := &VersionConstraintMatcher{
+		constraints: map[string]*Constraint{},
+	}
+	var errs []error
+	for _, name := range sortedKeys(constraints) {
+		c, err := NewConstraint(constraints[name])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		m.constraints[name] = c
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Set sets the constraint of the named dependency.
+func (m *VersionConstraintMatcher) Set(name string, c *Constraint) *VersionConstraintMatcher {
+	m.constraints[name] = c
+	return m
+}
+
+// Match checks the version of each dependency that has a constraint and reports per dependency
+// whether it is satisfied. Dependencies missing from dependencies are not satisfied, dependencies
+// without a constraint are ignored. Unparsable versions are not satisfied and reported in the returned error.
+func (m *VersionConstraintMatcher) Match(dependencies map[string]string) (map[string]bool, error) {
+	res := make(map[string]bool, len(m.constraints))
+	var errs []error
+	for _, name := range sortedKeys(m.constraints) {
+		res[name] = false
+		str, ok := dependencies[name]
+		if !ok {
+			continue
+		}
+		v, err := NewFromString(str)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		res[name] = m.constraints[name].Check(v)
+	}
+	return res, errors.Join(errs...)
+}
+
+// MatchAll reports whether all constrained dependencies are satisfied, see Match.
+func (m *VersionConstraintMatcher) MatchAll(dependencies map[string]string) (bool, error) {
+	res, err := m.Match(dependencies)
+	if err != nil {
+		return false, err
+	}
+	for _, ok := range res {
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
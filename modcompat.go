@@ -0,0 +1,99 @@
+package semver
+
+import "fmt"
+
+// The Mod* functions mirror the string based API of golang.org/x/mod/semver, to ease migrating from it.
+// Versions must start with "v", may use the shorthands vMAJOR and vMAJOR.MINOR (without suffix or metadata),
+// must not have leading zeros and must follow the SemVer spec otherwise. As the only known divergence,
+// components larger than MaxComponent are invalid here, while x/mod accepts numbers of any size.
+
+func modParse(str string) (*Version, bool) {
+	if len(str) == 0 || str[0] != 'v' {
+		return nil, false
+	}
+	res, ok := scanVersion(str)
+	if !ok || res.start != 0 {
+		return nil, false
+	}
+	for i := 0; i < res.count; i++ {
+		if len(res.components[i]) > 1 && res.components[i][0] == '0' {
+			return nil, false
+		}
+	}
+	if res.count < 3 && res.end != len(str) {
+		return nil, false
+	}
+	version, err := NewFromStringWithOptions(str, ParseOptions{AllowLeadingV: true, AllowBuildMeta: true, StrictPreRelease: true})
+	if err != nil {
+		return nil, false
+	}
+	return version, true
+}
+
+// ModIsValid reports whether str is a valid version in the sense of x/mod/semver.IsValid.
+func ModIsValid(str string) bool {
+	_, ok := modParse(str)
+	return ok
+}
+
+// ModCanonical returns the canonical form of str, "vMAJOR.MINOR.PATCH[-PRERELEASE]", or "" if it is invalid.
+func ModCanonical(str string) string {
+	version, ok := modParse(str)
+	if !ok {
+		return ""
+	}
+	version.metadata = ""
+	return version.LongString()
+}
+
+// ModMajor returns the major version prefix of str, e.g. "v2" for "v2.1.0", or "" if it is invalid.
+func ModMajor(str string) string {
+	version, ok := modParse(str)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("v%d", version.major)
+}
+
+// ModMajorMinor returns the major.minor version prefix of str, e.g. "v2.1" for "v2.1.0", or "" if it is invalid.
+func ModMajorMinor(str string) string {
+	version, ok := modParse(str)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("v%d.%d", version.major, version.minor)
+}
+
+// ModPrerelease returns the pre-release suffix of str including the "-", or "" if there is none or str is invalid.
+func ModPrerelease(str string) string {
+	version, ok := modParse(str)
+	if !ok || version.suffix == "" {
+		return ""
+	}
+	return "-" + version.suffix
+}
+
+// ModBuild returns the build metadata of str including the "+", or "" if there is none or str is invalid.
+func ModBuild(str string) string {
+	version, ok := modParse(str)
+	if !ok || version.metadata == "" {
+		return ""
+	}
+	return "+" + version.metadata
+}
+
+// ModCompare compares a and b like x/mod/semver.Compare: invalid versions are equal to each other
+// and less than all valid ones, build metadata is ignored.
+func ModCompare(a, b string) int {
+	va, aok := modParse(a)
+	vb, bok := modParse(b)
+	switch {
+	case !aok && !bok:
+		return 0
+	case !aok:
+		return -1
+	case !bok:
+		return 1
+	}
+	return compare(va, vb)
+}
@@ -0,0 +1,45 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Normalize returns a canonical copy of the version: all three components are rendered, empty pre-release
+// and metadata identifiers are dropped and leading zeros are removed from numeric pre-release identifiers,
+// so v1.2-rc.01 becomes v1.2.0-rc.1. Normalizing a normalized version doesn't change it.
+func (v *Version) Normalize() *Version {
+	ids := v.PreReleaseIdentifiers()
+	for i, id := range ids {
+		if isNumeric(id) {
+			ids[i] = strings.TrimLeft(id, "0")
+			if ids[i] == "" {
+				ids[i] = "0"
+			}
+		}
+	}
+	res := v.Core().SetSuffix(ids...).SetPrecision(3)
+	metadata := []string{}
+	for _, id := range strings.Split(v.metadata, ".") {
+		if id != "" {
+			metadata = append(metadata, id)
+		}
+	}
+	res.metadata = strings.Join(metadata, ".")
+	return res
+}
+
+// NormalizeString parses str, including build metadata, and returns the LongString of its normalized form,
+// e.g. "v1.2.0" for "V1.2", "1.2.0" and "v1.2.0+". It returns an error if the normalized form
+// still isn't a valid version, e.g. for "1.2.3 foo", see Validate.
+func NormalizeString(str string) (string, error) {
+	version, err := NewFromStringWithOptions(str, ParseOptions{AllowLeadingV: true, AllowBuildMeta: true, AllowCoercion: true})
+	if err != nil {
+		return "", err
+	}
+	res := version.Normalize()
+	if err := res.Validate(); err != nil {
+		return "", fmt.Errorf("can't normalize %q: %w", str, err)
+	}
+	return res.LongString(), nil
+}
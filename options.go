@@ -0,0 +1,199 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ParseOptions controls how NewFromStringWithOptions parses a version string.
+type ParseOptions struct {
+	// RequireAllComponents rejects versions that don't specify major, minor and patch.
+	RequireAllComponents bool
+	// AllowLeadingV accepts an optional "v" or "V" in front of the version.
+	AllowLeadingV bool
+	// AllowBuildMeta accepts build metadata after a "+", otherwise versions with build metadata are rejected.
+	AllowBuildMeta bool
+	// AllowCoercion extracts a version from surrounding text, e.g. "release 1.2.3",
+	// and accepts a suffix that isn't separated by a "-".
+	AllowCoercion bool
+	// StrictPreRelease validates the pre-release identifiers, and the build metadata if AllowBuildMeta is set,
+	// according to the SemVer spec.
+	StrictPreRelease bool
+	// TrimWhitespace removes leading and trailing whitespace before parsing.
+	TrimWhitespace bool
+	// CustomPrefixes are stripped from the input, the first one that matches wins.
+	CustomPrefixes []string
+	// RequiredPrefix must be present at the start of the input and is stripped before parsing.
+	RequiredPrefix string
+}
+
+func defaultParseOptions() ParseOptions {
+	return ParseOptions{
+		RequireAllComponents: false,
+		AllowLeadingV:        true,
+		AllowBuildMeta:       true,
+		AllowCoercion:        true,
+		StrictPreRelease:     false,
+	}
+}
+
+// DefaultParseOptions matches the behavior of NewFromString.
+var DefaultParseOptions = defaultParseOptions()
+
+// StrictParseOptions only accept versions that follow the SemVer spec, with an optional leading "v".
+var StrictParseOptions = ParseOptions{
+	RequireAllComponents: true,
+	AllowLeadingV:        true,
+	AllowBuildMeta:       true,
+	AllowCoercion:        false,
+	StrictPreRelease:     true,
+}
+
+// NewFromStringWithOptions parses a version string using the given options.
+// The input is processed in this order: whitespace is trimmed, a custom prefix is stripped,
+// the required prefix is checked and stripped, and the rest is parsed as a version.
+func NewFromStringWithOptions(str string, opts ParseOptions) (*Version, error) {
+	input := str
+	base := 0 // offset of str in input
+	if opts.TrimWhitespace {
+		trimmed := strings.TrimLeftFunc(str, unicode.IsSpace)
+		base += len(str) - len(trimmed)
+		str = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+	}
+	for _, p := range opts.CustomPrefixes {
+		if p != "" && strings.HasPrefix(str, p) {
+			base += len(p)
+			str = str[len(p):]
+			break
+		}
+	}
+	if opts.RequiredPrefix != "" {
+		if !strings.HasPrefix(str, opts.RequiredPrefix) {
+			return New(), &InvalidVersionError{Input: input, Reason: fmt.Sprintf("missing prefix %q", opts.RequiredPrefix), Offset: base, Err: ErrInvalidFormat}
+		}
+		base += len(opts.RequiredPrefix)
+		str = str[len(opts.RequiredPrefix):]
+	}
+
+	core, metadata, hasMetadata := str, "", false
+	if !opts.AllowCoercion {
+		if i := strings.IndexByte(str, '+'); i >= 0 {
+			core, metadata, hasMetadata = str[:i], str[i+1:], true
+		}
+		if res, ok := scanVersion(core); ok && !res.exact(core) {
+			offset := 0
+			if res.start == 0 {
+				offset = res.end
+			}
+			return optionsError(input, "invalid version format", "", base+offset)
+		}
+	}
+	version, err := parse(core)
+	if err != nil {
+		e := *err.(*InvalidVersionError)
+		e.Input = input
+		e.Offset += base
+		return version, &e
+	}
+	if hasMetadata {
+		version.metadata = metadata
+	} else {
+		hasMetadata = strings.IndexByte(str, '+') >= 0
+	}
+	if !opts.AllowBuildMeta && hasMetadata {
+		return optionsError(input, "build metadata not allowed", "metadata", base+strings.IndexByte(str, '+'))
+	}
+	if !opts.AllowLeadingV || opts.RequireAllComponents || opts.StrictPreRelease {
+		res, _ := scanVersion(core)
+		if !opts.AllowLeadingV && res.prefixed {
+			return optionsError(input, "leading v not allowed", "", base+res.start)
+		}
+		if opts.RequireAllComponents && res.count != 3 {
+			component := "minor"
+			if res.count == 2 {
+				component = "patch"
+			}
+			return optionsError(input, "missing version components", component, base+res.end)
+		}
+		if opts.StrictPreRelease {
+			if err := ValidateSuffix(version.suffix); err != nil {
+				offset := res.end
+				if res.dash {
+					offset++
+				}
+				return optionsError(input, err.Error(), "suffix", base+offset)
+			}
+			if hasMetadata {
+				err := validateIdentifiers("metadata", version.metadata, true)
+				if version.metadata == "" {
+					err = fmt.Errorf("empty metadata")
+				}
+				if err != nil {
+					return optionsError(input, err.Error(), "metadata", base+strings.IndexByte(str, '+')+1)
+				}
+			}
+		}
+	}
+	return version, nil
+}
+
+func optionsError(input, reason, component string, offset int) (*Version, error) {
+	return New(), &InvalidVersionError{Input: input, Reason: reason, Component: component, Offset: offset, Err: ErrInvalidFormat}
+}
+
+// ParseOption configures Parse.
+type ParseOption func(o *ParseOptions)
+
+// Parse parses a version string. Without options it behaves like NewFromString. Options are applied
+// in the order given, so a later option overrides an earlier one that sets the same field.
+func Parse(str string, opts ...ParseOption) (*Version, error) {
+	if len(opts) == 0 {
+		return NewFromStringWithOptions(str, defaultParseOptions())
+	}
+	o := defaultParseOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return NewFromStringWithOptions(str, o)
+}
+
+// WithStrict only accepts versions that follow the SemVer spec, like StrictParseOptions.
+// It doesn't change how prefixes are handled.
+func WithStrict() ParseOption {
+	return func(o *ParseOptions) {
+		o.RequireAllComponents = true
+		o.AllowBuildMeta = true
+		o.AllowCoercion = false
+		o.StrictPreRelease = true
+	}
+}
+
+// WithRequiredPrefix requires the input to start with prefix, e.g. "v".
+func WithRequiredPrefix(prefix string) ParseOption {
+	return func(o *ParseOptions) {
+		o.RequiredPrefix = prefix
+	}
+}
+
+// WithoutPrefix rejects versions with a leading "v" or "V". It also clears a required prefix.
+func WithoutPrefix() ParseOption {
+	return func(o *ParseOptions) {
+		o.AllowLeadingV = false
+		o.RequiredPrefix = ""
+	}
+}
+
+// WithCustomPrefixes strips the first matching prefix, e.g. "release-", before parsing.
+func WithCustomPrefixes(prefixes ...string) ParseOption {
+	return func(o *ParseOptions) {
+		o.CustomPrefixes = prefixes
+	}
+}
+
+// WithWhitespaceTrim removes leading and trailing whitespace before parsing.
+func WithWhitespaceTrim() ParseOption {
+	return func(o *ParseOptions) {
+		o.TrimWhitespace = true
+	}
+}
@@ -0,0 +1,125 @@
+package semver
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseAll parses every string and returns the valid versions in input order.
+// If any strings fail to parse, the returned error lists all of them by index and value.
+func ParseAll(strs []string) ([]*Version, error) {
+	res := []*Version{}
+	errs := []error{}
+	for i, str := range strs {
+		version, err := NewFromString(str)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("version %d (%q): %w", i, str, err))
+			continue
+		}
+		res = append(res, version)
+	}
+	return res, errors.Join(errs...)
+}
+
+// ParseAllValid parses every string and returns the valid versions in input order,
+// along with the number of strings that were skipped because they failed to parse.
+func ParseAllValid(strs []string) ([]*Version, int) {
+	res := []*Version{}
+	skipped := 0
+	for _, str := range strs {
+		version, err := NewFromString(str)
+		if err != nil {
+			skipped++
+			continue
+		}
+		res = append(res, version)
+	}
+	return res, skipped
+}
+
+// ParseLines reads versions from r, one per line. Surrounding whitespace is trimmed and blank lines are skipped.
+// Lines that fail to parse are collected in the returned error with their line number,
+// the valid versions are returned in input order.
+func ParseLines(r io.Reader) ([]*Version, error) {
+	return parseLines(r, false)
+}
+
+func parseLines(r io.Reader, skipComments bool) ([]*Version, error) {
+	res := []*Version{}
+	errs := []error{}
+	err := scanLines(r, bufio.MaxScanTokenSize, func(n int, line string, v *Version, err error) bool {
+		if skipComments && strings.HasPrefix(line, "#") {
+			return true
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d (%q): %w", n, line, err))
+			return true
+		}
+		res = append(res, v)
+		return true
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return res, errors.Join(errs...)
+}
+
+// ParseLinesFunc reads r line by line and calls fn with every non-blank, trimmed line and the result of parsing it.
+// Reading stops when fn returns false. The returned error only reports failures to read from r.
+// Lines can be at most bufio.MaxScanTokenSize bytes long, see ParseLinesFuncWithBuffer for longer lines.
+func ParseLinesFunc(r io.Reader, fn func(line string, v *Version, err error) bool) error {
+	return ParseLinesFuncWithBuffer(r, bufio.MaxScanTokenSize, fn)
+}
+
+// ParseLinesFuncWithBuffer is like ParseLinesFunc but allows lines up to maxLineSize bytes.
+// A maxLineSize of 0 or less falls back to bufio.MaxScanTokenSize.
+func ParseLinesFuncWithBuffer(r io.Reader, maxLineSize int, fn func(line string, v *Version, err error) bool) error {
+	return scanLines(r, maxLineSize, func(_ int, line string, v *Version, err error) bool {
+		return fn(line, v, err)
+	})
+}
+
+// scanLines implements ParseLinesFuncWithBuffer and also passes the 1-based line number of each line to fn.
+func scanLines(r io.Reader, maxLineSize int, fn func(n int, line string, v *Version, err error) bool) error {
+	if maxLineSize <= 0 {
+		maxLineSize = bufio.MaxScanTokenSize
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, min(maxLineSize, bufio.MaxScanTokenSize)), maxLineSize)
+	n := 0
+	for scanner.Scan() {
+		n++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		version, err := NewFromString(line)
+		if !fn(n, line, version, err) {
+			break
+		}
+	}
+	return scanner.Err()
+}
+
+// ParseVersionsFromReader works like ParseLines but also skips lines starting with "#",
+// which makes it suitable for reading version files with comments.
+func ParseVersionsFromReader(r io.Reader) ([]*Version, error) {
+	return parseLines(r, true)
+}
+
+// WriteVersionsToWriter writes the versions to w, one per line, and returns the first write error.
+// Versions are written in their LongString form so they read back unchanged. Nil entries are skipped.
+func WriteVersionsToWriter(w io.Writer, versions []*Version) error {
+	for _, v := range versions {
+		if v == nil {
+			continue
+		}
+		if _, err := io.WriteString(w, v.LongString()+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
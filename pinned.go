@@ -0,0 +1,114 @@
+package semver
+
+// PinnedVersion is a read-only version. It only exposes methods that don't modify the version,
+// so accepting a *PinnedVersion documents that a function won't change the version passed to it.
+// It implements SemVer.
+type PinnedVersion struct {
+	v *Version
+}
+
+// Pin returns a read-only copy of v. Later changes to v don't affect the pinned version.
+func Pin(v *Version) *PinnedVersion {
+	return &PinnedVersion{v: v.clone()}
+}
+
+// Version returns a mutable copy of the pinned version.
+func (p *PinnedVersion) Version() *Version {
+	return p.v.clone()
+}
+
+func (p *PinnedVersion) GetMajor() int {
+	return p.v.GetMajor()
+}
+
+func (p *PinnedVersion) GetMinor() int {
+	return p.v.GetMinor()
+}
+
+func (p *PinnedVersion) GetPatch() int {
+	return p.v.GetPatch()
+}
+
+func (p *PinnedVersion) GetSuffix() string {
+	return p.v.GetSuffix()
+}
+
+func (p *PinnedVersion) GetMetadata() string {
+	return p.v.GetMetadata()
+}
+
+func (p *PinnedVersion) GetPrecision() int {
+	return p.v.GetPrecision()
+}
+
+func (p *PinnedVersion) ToInts() (major, minor, patch int) {
+	return p.v.ToInts()
+}
+
+func (p *PinnedVersion) PreReleaseIdentifiers() []string {
+	return p.v.PreReleaseIdentifiers()
+}
+
+func (p *PinnedVersion) Channel() VersionChannel {
+	return p.v.Channel()
+}
+
+func (p *PinnedVersion) ChannelName() string {
+	return p.v.ChannelName()
+}
+
+func (p *PinnedVersion) IsPreRelease() bool {
+	return p.v.IsPreRelease()
+}
+
+func (p *PinnedVersion) IsStable() bool {
+	return p.v.IsStable()
+}
+
+func (p *PinnedVersion) IsDevelopment() bool {
+	return p.v.IsDevelopment()
+}
+
+func (p *PinnedVersion) IsZero() bool {
+	return p.v.IsZero()
+}
+
+func (p *PinnedVersion) String() string {
+	return p.v.String()
+}
+
+func (p *PinnedVersion) LongString() string {
+	return p.v.LongString()
+}
+
+func (p *PinnedVersion) StringWithOptions(opts FormatOptions) string {
+	return p.v.StringWithOptions(opts)
+}
+
+// Compare compares the pinned version with other like Version.Compare.
+func (p *PinnedVersion) Compare(other SemVer) int {
+	if o, ok := other.(*PinnedVersion); ok {
+		return compare(p.v, o.v)
+	}
+	return p.v.Compare(other)
+}
+
+func (p *PinnedVersion) Equal(other *PinnedVersion) bool {
+	return p.v.Equal(other.v)
+}
+
+func (p *PinnedVersion) GreaterThan(other *PinnedVersion) bool {
+	return p.v.GreaterThan(other.v)
+}
+
+func (p *PinnedVersion) LessThan(other *PinnedVersion) bool {
+	return p.v.LessThan(other.v)
+}
+
+func (p *PinnedVersion) IsCompatibleWith(other *PinnedVersion) bool {
+	return p.v.IsCompatibleWith(other.v)
+}
+
+func (p *PinnedVersion) Validate() error {
+	return p.v.Validate()
+}
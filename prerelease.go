@@ -0,0 +1,287 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultPreReleaseChannels is the progression of pre-release channels used by BumpPreRelease
+// and NextPreReleaseChannel if no channels are passed.
+var defaultPreReleaseChannels = []string{"alpha", "beta", "rc"}
+
+// preReleaseChannels returns channels, or the default progression if there are none.
+func preReleaseChannels(channels []string) []string {
+	if len(channels) == 0 {
+		return defaultPreReleaseChannels
+	}
+	return channels
+}
+
+func preReleaseChannelIndex(channels []string, channel string) int {
+	for i, c := range channels {
+		if strings.EqualFold(c, channel) {
+			return i
+		}
+	}
+	return -1
+}
+
+// BumpPreRelease increments the trailing number of the suffix, e.g. "alpha.1" becomes "alpha.2",
+// or appends ".1" if there is none. channels is the progression of pre-release channels,
+// "alpha", "beta" and "rc" if none are given; after the last one follows the release.
+// A version in the last channel becomes the release. A release moves to the first pre-release of
+// the first channel of the next patch version, e.g. v1.2.3 becomes v1.2.4-alpha.1, so the result
+// is never lower than the version.
+func (v *Version) BumpPreRelease(channels ...string) *Version {
+	channels = preReleaseChannels(channels)
+	if v.suffix == "" {
+		return v.SetPatch(v.patch+1).SetSuffix(channels[0], "1")
+	}
+	ids := strings.Split(v.suffix, ".")
+	if i := preReleaseChannelIndex(channels, ids[0]); i >= 0 && i == len(channels)-1 {
+		return v.SetSuffix()
+	}
+	if n, err := strconv.Atoi(ids[len(ids)-1]); err == nil {
+		ids[len(ids)-1] = strconv.Itoa(n + 1)
+		return v.SetSuffix(ids...)
+	}
+	return v.SetSuffix(append(ids, "1")...)
+}
+
+// NextPreReleaseChannel moves the version to the first pre-release of the next channel, e.g. "alpha.3"
+// becomes "beta.1" and "rc.2" becomes the release. channels is handled like in BumpPreRelease.
+// Releases and versions that are not in one of the channels are left unchanged.
+func (v *Version) NextPreReleaseChannel(channels ...string) *Version {
+	channels = preReleaseChannels(channels)
+	if v.suffix == "" {
+		return v
+	}
+	i := preReleaseChannelIndex(channels, strings.Split(v.suffix, ".")[0])
+	switch {
+	case i < 0:
+		return v
+	case i == len(channels)-1:
+		return v.SetSuffix()
+	}
+	return v.SetSuffix(channels[i+1], "1")
+}
+
+// PreReleaseIdentifiers returns the dot separated identifiers of the suffix, leaving out empty ones.
+func (v *Version) PreReleaseIdentifiers() []string {
+	ids := []string{}
+	for _, id := range strings.Split(v.suffix, ".") {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// AddPreReleaseIdentifier appends id to the suffix.
+func (v *Version) AddPreReleaseIdentifier(id string) *Version {
+	return v.SetSuffix(append(v.PreReleaseIdentifiers(), id)...)
+}
+
+// SuffixElementCount returns the number of identifiers in the suffix, see PreReleaseIdentifiers.
+func (v *Version) SuffixElementCount() int {
+	return len(v.PreReleaseIdentifiers())
+}
+
+// AppendSuffixElement appends id to the suffix, e.g. "linux" turns v1.2.3-rc.1 into v1.2.3-rc.1.linux.
+// Unlike AddPreReleaseIdentifier it returns an error and leaves the version unchanged if id is not
+// a single valid identifier. Like the other suffix element methods it drops empty identifiers.
+func (v *Version) AppendSuffixElement(id string) error {
+	if err := validateIdentifierList("pre-release", []string{id}, false); err != nil {
+		return err
+	}
+	v.SetSuffix(append(v.PreReleaseIdentifiers(), id)...)
+	return nil
+}
+
+// ReplaceSuffixElement replaces the identifier at index i with id.
+// It returns an error if i is out of range or id is not a single valid identifier.
+func (v *Version) ReplaceSuffixElement(i int, id string) error {
+	ids := v.PreReleaseIdentifiers()
+	if i < 0 || i >= len(ids) {
+		return fmt.Errorf("suffix element index %d out of range, suffix has %d elements", i, len(ids))
+	}
+	if err := validateIdentifierList("pre-release", []string{id}, false); err != nil {
+		return err
+	}
+	ids[i] = id
+	v.SetSuffix(ids...)
+	return nil
+}
+
+// RemoveSuffixElement removes the identifier at index i, or returns an error if i is out of range.
+// Removing the last identifier turns the version into a release.
+func (v *Version) RemoveSuffixElement(i int) error {
+	ids := v.PreReleaseIdentifiers()
+	if i < 0 || i >= len(ids) {
+		return fmt.Errorf("suffix element index %d out of range, suffix has %d elements", i, len(ids))
+	}
+	v.SetSuffix(append(ids[:i], ids[i+1:]...)...)
+	return nil
+}
+
+// ValidateSuffix checks a suffix against the SemVer spec: its dot separated identifiers must be non-empty,
+// may only contain ASCII letters, digits and "-", and numeric identifiers must not have leading zeros.
+// An empty suffix is valid.
+func ValidateSuffix(suffix string) error {
+	return validateIdentifiers("pre-release", suffix, false)
+}
+
+func validateIdentifiers(kind, str string, allowLeadingZeros bool) error {
+	if str == "" {
+		return nil
+	}
+	for _, id := range strings.Split(str, ".") {
+		if id == "" {
+			return fmt.Errorf("empty %s identifier", kind)
+		}
+		numeric := true
+		for _, c := range id {
+			switch {
+			case c >= '0' && c <= '9':
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '-':
+				numeric = false
+			default:
+				return fmt.Errorf("invalid character %q in %s identifier", c, kind)
+			}
+		}
+		if !allowLeadingZeros && numeric && len(id) > 1 && id[0] == '0' {
+			return fmt.Errorf("leading zero in %s identifier %s", kind, id)
+		}
+	}
+	return nil
+}
+
+func isNumeric(id string) bool {
+	for i := 0; i < len(id); i++ {
+		if !isDigit(id[i]) {
+			return false
+		}
+	}
+	return id != ""
+}
+
+// VersionChannel is the maturity of a version, derived from its suffix. The channels are ordered
+// from least to most mature, so they can be compared with < and >.
+type VersionChannel int
+
+const (
+	ChannelPreRelease VersionChannel = iota // any other pre-release, e.g. v1.2.3-nightly.5 or v1.2.3-1
+	ChannelAlpha                            // e.g. v1.2.3-alpha.1
+	ChannelBeta                             // e.g. v1.2.3-beta.1
+	ChannelRC                               // e.g. v1.2.3-rc.1
+	ChannelRelease                          // no suffix
+)
+
+func (c VersionChannel) String() string {
+	switch c {
+	case ChannelAlpha:
+		return "alpha"
+	case ChannelBeta:
+		return "beta"
+	case ChannelRC:
+		return "rc"
+	case ChannelRelease:
+		return "stable" // same as Version.ChannelName
+	}
+	return "prerelease"
+}
+
+// Channel returns the channel of the version based on its first pre-release identifier,
+// which is matched case-insensitively, e.g. ChannelBeta for v1.2.3-Beta.2.
+func (v *Version) Channel() VersionChannel {
+	switch v.ChannelName() {
+	case "stable":
+		return ChannelRelease
+	case "alpha":
+		return ChannelAlpha
+	case "beta":
+		return ChannelBeta
+	case "rc":
+		return ChannelRC
+	}
+	return ChannelPreRelease
+}
+
+// ChannelName returns the name of the release channel of the version: "stable" if it has no suffix,
+// otherwise the first pre-release identifier in lower case, e.g. "nightly" for v1.2.3-Nightly.2.
+// Pre-releases whose first identifier is numeric, e.g. v1.2.3-1, are in the "prerelease" channel.
+func (v *Version) ChannelName() string {
+	ids := v.PreReleaseIdentifiers()
+	if len(ids) == 0 {
+		return "stable"
+	}
+	if isNumeric(ids[0]) {
+		return "prerelease"
+	}
+	return strings.ToLower(ids[0])
+}
+
+// FilterByChannel returns the versions in the given channel, preserving their order.
+func FilterByChannel(versions []*Version, ch VersionChannel) []*Version {
+	return filter(versions, func(v *Version) bool { return v.Channel() == ch })
+}
+
+// FilterByChannelName returns the versions in the named channel (see Version.ChannelName), preserving their order.
+func FilterByChannelName(versions []*Version, channel string) []*Version {
+	return filter(versions, func(v *Version) bool { return v.ChannelName() == strings.ToLower(channel) })
+}
+
+// comparePreRelease compares suffixes by SemVer precedence: a version without suffix is greater than
+// one with, identifiers are compared from left to right, numeric ones numerically and lower than
+// alphanumeric ones, and a suffix with fewer identifiers is lower if all preceding ones are equal.
+func comparePreRelease(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "" {
+		return 1
+	}
+	if b == "" {
+		return -1
+	}
+	for {
+		ia, ra, aok := strings.Cut(a, ".")
+		ib, rb, bok := strings.Cut(b, ".")
+		if c := compareIdentifier(ia, ib); c != 0 {
+			return c
+		}
+		switch {
+		case !aok && !bok:
+			return 0
+		case !aok:
+			return -1
+		case !bok:
+			return 1
+		}
+		a, b = ra, rb
+	}
+}
+
+// compareIdentifier compares numeric identifiers numerically. Identifiers with leading zeros, which
+// the spec forbids but the lenient parser accepts, are only equal if they are identical, e.g. "01"
+// sorts right after "1", so equality agrees with Key, Hash and the other functions keyed by the suffix.
+func compareIdentifier(a, b string) int {
+	an, bn := isNumeric(a), isNumeric(b)
+	switch {
+	case an && bn:
+		ta, tb := strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+		if len(ta) != len(tb) {
+			return cmpInt(len(ta), len(tb))
+		}
+		if c := strings.Compare(ta, tb); c != 0 {
+			return c
+		}
+		return cmpInt(len(a), len(b))
+	case an:
+		return -1
+	case bn:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,68 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const pseudoVersionTimestampFormat = "20060102150405"
+
+// IsPseudoVersion reports whether v is a Go module pseudo-version, e.g. v0.0.0-20230912144702-c363fe2c2ed8.
+func IsPseudoVersion(v *Version) bool {
+	_, _, _, err := PseudoVersionParts(v)
+	return err == nil
+}
+
+// PseudoVersionParts decomposes a Go module pseudo-version into the version it is based on,
+// the commit timestamp and the revision. The three forms used by the Go toolchain are supported:
+//
+//	vX.0.0-yyyymmddhhmmss-abcdef123456      no earlier tag, base is nil
+//	vX.Y.Z-pre.0.yyyymmddhhmmss-abcdef123456 based on the pre-release vX.Y.Z-pre
+//	vX.Y.Z-0.yyyymmddhhmmss-abcdef123456     based on the release vX.Y.(Z-1)
+func PseudoVersionParts(v *Version) (base *Version, timestamp time.Time, revision string, err error) {
+	invalid := func(reason string) (*Version, time.Time, string, error) {
+		return nil, time.Time{}, "", fmt.Errorf("invalid pseudo-version %s: %s", v, reason)
+	}
+	i := strings.LastIndex(v.suffix, "-")
+	if i < 0 || !isAlphanumeric(v.suffix[i+1:]) {
+		return invalid("missing revision")
+	}
+	rest, revision := v.suffix[:i], v.suffix[i+1:]
+	if len(rest) < len(pseudoVersionTimestampFormat) {
+		return invalid("missing timestamp")
+	}
+	prefix, ts := rest[:len(rest)-len(pseudoVersionTimestampFormat)], rest[len(rest)-len(pseudoVersionTimestampFormat):]
+	if !isNumeric(ts) {
+		return invalid("malformed timestamp")
+	}
+	timestamp, err = time.Parse(pseudoVersionTimestampFormat, ts)
+	if err != nil {
+		return invalid("malformed timestamp")
+	}
+	switch {
+	case prefix == "":
+		if v.minor != 0 || v.patch != 0 {
+			return invalid("version without base must be vX.0.0")
+		}
+	case prefix == "0.":
+		if v.patch == 0 {
+			return invalid("release based version must have a patch version")
+		}
+		base = NewFromInts(v.major, v.minor, v.patch-1)
+	case strings.HasSuffix(prefix, ".0.") && len(prefix) > 3:
+		base = NewFromIntsWithSuffix(v.major, v.minor, v.patch, prefix[:len(prefix)-3])
+	default:
+		return invalid("malformed suffix")
+	}
+	return base, timestamp, revision, nil
+}
+
+func isAlphanumeric(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if !isWordByte(str[i]) || str[i] == '_' {
+			return false
+		}
+	}
+	return str != ""
+}
@@ -0,0 +1,126 @@
+package semver
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type randomOptions struct {
+	stableOnly   bool
+	maxComponent int
+}
+
+// RandomOption configures RandomVersion.
+type RandomOption func(o *randomOptions)
+
+// RandomStableOnly makes RandomVersion generate versions without suffix.
+func RandomStableOnly() RandomOption {
+	return func(o *randomOptions) {
+		o.stableOnly = true
+	}
+}
+
+// RandomMaxComponent limits the components generated by RandomVersion to 0..max.
+func RandomMaxComponent(max int) RandomOption {
+	return func(o *randomOptions) {
+		o.maxComponent = max
+	}
+}
+
+const randomIdentifierChars = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ-"
+
+func randomIdentifiers(r *rand.Rand, leadingZeros bool) []string {
+	ids := make([]string, 1+r.Intn(3))
+	for i := range ids {
+		if r.Intn(3) == 0 {
+			ids[i] = strconv.Itoa(r.Intn(100))
+			continue
+		}
+		b := make([]byte, 1+r.Intn(8))
+		for j := range b {
+			b[j] = randomIdentifierChars[r.Intn(len(randomIdentifierChars))]
+		}
+		if !leadingZeros && isNumeric(string(b)) && b[0] == '0' {
+			b[0] = 'x'
+		}
+		ids[i] = string(b)
+	}
+	return ids
+}
+
+// RandomVersion returns a random valid version, with a mix of zero components, multi-identifier
+// pre-releases and build metadata. Every generated version passes Validate.
+func RandomVersion(r *rand.Rand, opts ...RandomOption) *Version {
+	o := randomOptions{
+		stableOnly:   false,
+		maxComponent: 100,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	o.maxComponent = max(0, min(o.maxComponent, MaxComponent))
+	component := func() int {
+		if r.Intn(4) == 0 {
+			return 0
+		}
+		return r.Intn(o.maxComponent + 1)
+	}
+	v := NewFromInts(component(), component(), component())
+	if !o.stableOnly && r.Intn(2) == 0 {
+		v.SetSuffix(randomIdentifiers(r, false)...)
+	}
+	if r.Intn(4) == 0 {
+		v.metadata = strings.Join(randomIdentifiers(r, true), ".")
+	}
+	return v
+}
+
+// Generate implements testing/quick.Generator, so *Version arguments of property based tests are random versions.
+func (v *Version) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(RandomVersion(r, RandomMaxComponent(size)))
+}
+
+// TrickyVersionStrings returns inputs that version parsers commonly get wrong,
+// useful for seeding fuzz tests.
+func TrickyVersionStrings() []string {
+	return []string{
+		"",
+		" ",
+		"v",
+		"1",
+		"1.2",
+		"1.2.3",
+		"v1.2.3",
+		"V1.2.3",
+		"01.2.3",
+		"1.02.3",
+		"1.2.03",
+		"1.2.3-01",
+		"1.2.3-0",
+		"1.2.3-",
+		"1.2.3+",
+		"1.2.3-+",
+		"1.2.3-a..b",
+		"1.2.3-.a",
+		"1.2.3-a.",
+		"1.2.3+a..b",
+		"1.2.3-rc.1+build.5",
+		"1.2.3+build-5.x",
+		"1.2.3.4",
+		"127.0.0.1",
+		"1.2.3 ",
+		" 1.2.3",
+		"1.2.3\n",
+		"1.2.3-héllo",
+		"1.2.3-a b",
+		"1.2.3-🚀",
+		"99999999999999999999.0.0",
+		"9223372036854775807.0.0",
+		"9007199254740992.0.0",
+		"-1.2.3",
+		"1.-2.3",
+		"1.2.3-alpha.beta.gamma.delta.epsilon.zeta.eta.theta.iota.kappa",
+	}
+}
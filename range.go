@@ -0,0 +1,85 @@
+package semver
+
+// VersionRange is a range of versions between Start and End.
+// A nil Start or End leaves the range unbounded on that side.
+type VersionRange struct {
+	Start        *Version
+	End          *Version
+	IncludeStart bool
+	IncludeEnd   bool
+}
+
+func NewVersionRange(start, end *Version, includeStart, includeEnd bool) *VersionRange {
+	return &VersionRange{
+		Start:        start,
+		End:          end,
+		IncludeStart: includeStart,
+		IncludeEnd:   includeEnd,
+	}
+}
+
+// Contains reports whether v lies within the range.
+func (r *VersionRange) Contains(v *Version) bool {
+	if v == nil {
+		return false
+	}
+	if r.Start != nil {
+		c := compare(v, r.Start)
+		if c < 0 || (c == 0 && !r.IncludeStart) {
+			return false
+		}
+	}
+	if r.End != nil {
+		c := compare(v, r.End)
+		if c > 0 || (c == 0 && !r.IncludeEnd) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *VersionRange) isEmpty() bool {
+	if r.Start == nil || r.End == nil {
+		return false
+	}
+	c := compare(r.Start, r.End)
+	return c > 0 || (c == 0 && !(r.IncludeStart && r.IncludeEnd))
+}
+
+// Intersection returns the range of versions contained in both r and other,
+// and false if there are none.
+func (r *VersionRange) Intersection(other *VersionRange) (*VersionRange, bool) {
+	res := NewVersionRange(r.Start, r.End, r.IncludeStart, r.IncludeEnd)
+	if other.Start != nil {
+		c := 1
+		if res.Start != nil {
+			c = compare(other.Start, res.Start)
+		}
+		if c > 0 {
+			res.Start, res.IncludeStart = other.Start, other.IncludeStart
+		} else if c == 0 {
+			res.IncludeStart = res.IncludeStart && other.IncludeStart
+		}
+	}
+	if other.End != nil {
+		c := -1
+		if res.End != nil {
+			c = compare(other.End, res.End)
+		}
+		if c < 0 {
+			res.End, res.IncludeEnd = other.End, other.IncludeEnd
+		} else if c == 0 {
+			res.IncludeEnd = res.IncludeEnd && other.IncludeEnd
+		}
+	}
+	if res.isEmpty() {
+		return nil, false
+	}
+	return res, true
+}
+
+// Overlaps reports whether r and other have any versions in common.
+func (r *VersionRange) Overlaps(other *VersionRange) bool {
+	_, ok := r.Intersection(other)
+	return ok
+}
@@ -0,0 +1,35 @@
+package semver
+
+import (
+	"strings"
+	"text/template"
+)
+
+// Render executes tmpl as a text/template with the fields Major, Minor, Patch, Suffix, Metadata
+// and Full (the string representation), e.g. "build_{{.Major}}_{{.Minor}}_{{.Patch}}".
+func (v *Version) Render(tmpl string) (string, error) {
+	t, err := template.New("version").Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+	data := struct {
+		Major    int
+		Minor    int
+		Patch    int
+		Suffix   string
+		Metadata string
+		Full     string
+	}{
+		Major:    v.major,
+		Minor:    v.minor,
+		Patch:    v.patch,
+		Suffix:   v.suffix,
+		Metadata: v.metadata,
+		Full:     v.String(),
+	}
+	var sb strings.Builder
+	if err := t.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
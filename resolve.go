@@ -0,0 +1,54 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNoVersionAvailable is returned by ParseOrResolve when a sentinel has no versions to select from.
+var ErrNoVersionAvailable = errors.New("no version available")
+
+// Sentinels maps the names accepted by ParseOrResolve to functions selecting a version from the
+// available ones, returning nil if none qualifies. Names are lower case, add entries to support more.
+var Sentinels = map[string]func(available []*Version) *Version{
+	"latest": Max,
+	"stable": func(available []*Version) *Version { return Max(FilterStable(available)) },
+}
+
+// ParseOrResolve resolves input case-insensitively as one of the Sentinels, e.g. "latest" for the
+// highest available version or "stable" for the highest one without suffix. Any other input is parsed
+// without coercion, so typos like "latest-1" are errors rather than versions. Inputs that are neither
+// a sentinel nor a version, like "newest", are reported as unknown sentinels.
+func ParseOrResolve(input string, available []*Version) (*Version, error) {
+	name := strings.ToLower(strings.TrimSpace(input))
+	selector, ok := Sentinels[name]
+	if !ok {
+		v, err := NewFromStringWithOptions(input, ParseOptions{AllowLeadingV: true, AllowBuildMeta: true})
+		if err != nil {
+			if isSentinelName(name) {
+				return nil, fmt.Errorf("unknown sentinel %q", input)
+			}
+			return nil, err
+		}
+		return v, nil
+	}
+	if len(available) == 0 {
+		return nil, fmt.Errorf("resolving %q: %w", input, ErrNoVersionAvailable)
+	}
+	v := selector(available)
+	if v == nil {
+		return nil, fmt.Errorf("resolving %q: no matching version among %d: %w", input, len(available), ErrNoVersionAvailable)
+	}
+	return v, nil
+}
+
+// isSentinelName reports whether str looks like a sentinel name rather than a version, i.e. consists of letters only.
+func isSentinelName(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if str[i] < 'a' || str[i] > 'z' {
+			return false
+		}
+	}
+	return str != "" && str != "v"
+}
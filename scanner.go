@@ -0,0 +1,93 @@
+package semver
+
+type byteString interface {
+	~string | ~[]byte
+}
+
+// scanResult describes the first version found in a string. The accepted grammar is
+// an optional "v" or "V", one to three dot separated digit runs, an optional "-" and
+// the rest of the line as suffix. Text in front of the version is skipped.
+type scanResult[T byteString] struct {
+	start      int    // offset of the version, including the prefix
+	prefixed   bool   // whether the version starts with "v" or "V"
+	components [3]T   // the digit runs
+	offsets    [3]int // offsets of the digit runs
+	count      int    // number of digit runs
+	end        int    // offset after the last digit run
+	dash       bool   // whether the suffix is separated by a "-"
+	suffix     T
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func scanVersion[T byteString](str T) (scanResult[T], bool) {
+	res := scanResult[T]{}
+	i := 0
+	for ; i < len(str); i++ {
+		if isDigit(str[i]) {
+			break
+		}
+		if (str[i] == 'v' || str[i] == 'V') && i+1 < len(str) && isDigit(str[i+1]) {
+			res.prefixed = true
+			break
+		}
+	}
+	if i == len(str) {
+		return res, false
+	}
+	res.start = i
+	if res.prefixed {
+		i++
+	}
+	for res.count < 3 {
+		j := i
+		for j < len(str) && isDigit(str[j]) {
+			j++
+		}
+		res.components[res.count] = str[i:j]
+		res.offsets[res.count] = i
+		res.count++
+		i = j
+		if res.count == 3 || i+1 >= len(str) || str[i] != '.' || !isDigit(str[i+1]) {
+			break
+		}
+		i++
+	}
+	res.end = i
+	if i < len(str) && str[i] == '-' {
+		res.dash = true
+		i++
+	}
+	j := i
+	for j < len(str) && str[j] != '\n' {
+		j++
+	}
+	res.suffix = str[i:j]
+	return res, true
+}
+
+// exact reports whether the version spans the whole string and the suffix, if any, is separated by a "-".
+func (r scanResult[T]) exact(str T) bool {
+	if r.start != 0 {
+		return false
+	}
+	if r.end == len(str) {
+		return true
+	}
+	return r.dash && len(r.suffix) > 0 && r.end+1+len(r.suffix) == len(str)
+}
+
+// atoi converts a run of digits, reporting false if the value exceeds MaxComponent.
+func atoi[T byteString](digits T) (int, bool) {
+	n := 0
+	for i := 0; i < len(digits); i++ {
+		d := int(digits[i] - '0')
+		if n > (MaxComponent-d)/10 {
+			return 0, false
+		}
+		n = n*10 + d
+	}
+	return n, true
+}
@@ -0,0 +1,545 @@
+package semver_test
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/toxyl/semver"
+)
+
+// benchVersions are realistic tags as found in git repositories and package registries.
+var benchVersions = []string{
+	"v1.2.3",
+	"v0.9.0",
+	"v2.0.0-rc.1",
+	"v2.0.0-rc.2+build.5",
+	"v1.18.0-beta.3",
+	"v10.4.1-alpha.1.preview",
+	"v0.0.0-20240102150405-abcdef123456",
+	"v3.1.4+sha.9f8e7d6",
+	"v1.0.0-x.7.z.92",
+	"v5.12.0",
+}
+
+// randomVersions returns n reproducible random versions with pre-releases and metadata.
+func randomVersions(n int) []*semver.Version {
+	r := rand.New(rand.NewSource(1))
+	vs := make([]*semver.Version, n)
+	for i := range vs {
+		vs[i] = semver.RandomVersion(r)
+	}
+	return vs
+}
+
+func BenchmarkSortVersions(b *testing.B) {
+	for _, n := range []int{10, 100, 1000, 10000} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			vs := randomVersions(n)
+			buf := make([]*semver.Version, n)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				copy(buf, vs)
+				semver.SortVersions(buf)
+			}
+		})
+	}
+}
+
+func BenchmarkNewVersionFromString(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := semver.NewFromString(benchVersions[i%len(benchVersions)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVersionString(b *testing.B) {
+	vs := make([]*semver.Version, len(benchVersions))
+	for i, s := range benchVersions {
+		vs[i] = semver.MustParse(s)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = vs[i%len(vs)].String()
+	}
+}
+
+// reSemVerReference is the regexp the parser used before it was replaced by a scanner.
+// It is kept as the reference for the accepted grammar.
+var reSemVerReference = regexp.MustCompile(`(?:v|V|)((?:\d+\.){0,2}\d+)-{0,1}(.*)`)
+
+// referenceParse parses str with reSemVerReference and splits the build metadata off the suffix
+// like the parser does.
+func referenceParse(str string) (major, minor, patch int, suffix, metadata string, ok bool) {
+	matches := reSemVerReference.FindStringSubmatch(str)
+	if len(matches) != 3 {
+		return 0, 0, 0, "", "", false
+	}
+	components := [3]int{}
+	for i, digits := range strings.Split(matches[1], ".") {
+		n, err := strconv.Atoi(digits)
+		if err != nil || n > semver.MaxComponent {
+			return 0, 0, 0, "", "", false
+		}
+		components[i] = n
+	}
+	suffix, metadata, _ = strings.Cut(matches[2], "+")
+	return components[0], components[1], components[2], suffix, metadata, true
+}
+
+// parserCorpus returns the tricky inputs, random valid versions and n random strings over the
+// characters that matter to the grammar.
+func parserCorpus(n int) []string {
+	r := rand.New(rand.NewSource(1))
+	corpus := semver.TrickyVersionStrings()
+	for _, v := range randomVersions(1000) {
+		corpus = append(corpus, v.String())
+	}
+	const chars = "0123456789..--++vVxa \n"
+	for i := 0; i < n; i++ {
+		b := make([]byte, r.Intn(16))
+		for j := range b {
+			b[j] = chars[r.Intn(len(chars))]
+		}
+		corpus = append(corpus, string(b))
+	}
+	return corpus
+}
+
+func TestParserMatchesReferenceRegexp(t *testing.T) {
+	for _, str := range parserCorpus(200000) {
+		major, minor, patch, suffix, metadata, ok := referenceParse(str)
+		v, err := semver.NewFromString(str)
+		if ok != (err == nil) {
+			t.Fatalf("%q: reference accepts: %v, parser error: %v", str, ok, err)
+		}
+		if !ok {
+			continue
+		}
+		if v.GetMajor() != major || v.GetMinor() != minor || v.GetPatch() != patch || v.GetSuffix() != suffix || v.GetMetadata() != metadata {
+			t.Fatalf("%q: parsed as %d.%d.%d-%q+%q, reference %d.%d.%d-%q+%q", str,
+				v.GetMajor(), v.GetMinor(), v.GetPatch(), v.GetSuffix(), v.GetMetadata(),
+				major, minor, patch, suffix, metadata)
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	b.Run("scanner", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = semver.NewFromString(benchVersions[i%len(benchVersions)])
+		}
+	})
+	b.Run("reference-regexp", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _, _, _, _, _ = referenceParse(benchVersions[i%len(benchVersions)])
+		}
+	})
+}
+
+func TestComparisonDoesNotAllocate(t *testing.T) {
+	a := semver.MustParse("v1.2.3-rc.1.alpha+build.5")
+	b := semver.MustParse("v1.2.3-rc.1.beta")
+	for name, fn := range map[string]func(){
+		"Compare":       func() { _ = a.Compare(b) },
+		"Equal":         func() { _ = a.Equal(b) },
+		"LessThan":      func() { _ = a.LessThan(b) },
+		"CompareRaw":    func() { _ = semver.CompareRaw(1, 2, 3, "rc.1.alpha", 1, 2, 3, "rc.1.beta") },
+		"Value.Compare": func() { _ = a.Comparable().Compare(b.Comparable()) },
+	} {
+		if allocs := testing.AllocsPerRun(100, fn); allocs != 0 {
+			t.Errorf("%s: %v allocs, want 0", name, allocs)
+		}
+	}
+}
+
+func TestParseBytesAllocations(t *testing.T) {
+	// the version itself is allocated, plus the suffix and metadata strings if present
+	for _, tc := range []struct {
+		input  string
+		allocs float64
+	}{
+		{"v1.2.3", 1},
+		{"v1.22.333-rc.1", 2},
+		{"v1.2.3-rc.1+build.5", 3},
+	} {
+		b := []byte(tc.input)
+		allocs := testing.AllocsPerRun(100, func() {
+			if _, err := semver.ParseBytes(b); err != nil {
+				t.Fatal(err)
+			}
+		})
+		if allocs > tc.allocs {
+			t.Errorf("ParseBytes(%q): %v allocs, want at most %v", tc.input, allocs, tc.allocs)
+		}
+	}
+}
+
+func BenchmarkCompare(b *testing.B) {
+	x := semver.MustParse("v1.2.3-rc.1.alpha")
+	y := semver.MustParse("v1.2.3-rc.1.beta")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = x.Compare(y)
+	}
+}
+
+func BenchmarkParseBytes(b *testing.B) {
+	inputs := make([][]byte, len(benchVersions))
+	for i, s := range benchVersions {
+		inputs[i] = []byte(s)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := semver.ParseBytes(inputs[i%len(inputs)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func FuzzNewVersionFromString(f *testing.F) {
+	for _, s := range semver.TrickyVersionStrings() {
+		f.Add(s)
+	}
+	for _, s := range benchVersions {
+		f.Add(s)
+	}
+	for _, s := range []string{"V1.2.3", "release-1.2.3", "go1.22", "1.2.3-", "v1.2.3-rc.1+", "x", "-1.2.3"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, str string) {
+		v, err := semver.NewFromString(str)
+		if err != nil {
+			return
+		}
+		out := v.String()
+		w, err := semver.NewFromString(out)
+		if err != nil {
+			t.Fatalf("%q: String() = %q doesn't parse: %v", str, out, err)
+		}
+		if !w.Equal(v) || w.GetMetadata() != v.GetMetadata() || w.String() != out {
+			t.Fatalf("%q: String() = %q parses as %q", str, out, w)
+		}
+	})
+}
+
+func TestSetStringRoundTrip(t *testing.T) {
+	long := strings.Repeat("x", 200)
+	for _, tc := range []struct {
+		major, minor, patch int
+		suffix              []string
+	}{
+		{0, 0, 0, nil},
+		{1, 0, 0, nil},
+		{0, 1, 0, nil},
+		{0, 0, 1, nil},
+		{1, 2, 3, nil},
+		{10, 20, 30, nil},
+		{semver.MaxComponent, semver.MaxComponent, semver.MaxComponent, nil},
+		{1, 0, 0, []string{"rc"}},
+		{1, 0, 0, []string{"rc", "1"}},
+		{0, 0, 0, []string{"alpha.1"}},
+		{1, 2, 3, []string{"rc.1.build", "7"}},
+		{1, 2, 3, []string{"0"}},
+		{1, 2, 3, []string{"x-y-z", "--"}},
+		{1, 2, 3, []string{long}},
+		{1, 2, 3, []string{long, long, "1"}},
+		{1, 2, 3, strings.Split("a.b.c.d.e.f.g.h.i.j.k.l.m.n.o.p", ".")},
+	} {
+		v := semver.New().Set(tc.major, tc.minor, tc.patch, tc.suffix...)
+		if v.Err() != nil {
+			t.Fatalf("Set(%d, %d, %d, %q): %v", tc.major, tc.minor, tc.patch, tc.suffix, v.Err())
+		}
+		str := v.String()
+		w, err := semver.NewFromString(str)
+		if err != nil {
+			t.Errorf("%q: %v", str, err)
+			continue
+		}
+		if !w.Equal(v) || w.GetMajor() != tc.major || w.GetMinor() != tc.minor || w.GetPatch() != tc.patch ||
+			w.GetSuffix() != strings.Join(tc.suffix, ".") || w.String() != str {
+			t.Errorf("%q parses as %q", str, w)
+		}
+	}
+}
+
+func TestSetStringRoundTripExhaustive(t *testing.T) {
+	for major := 0; major < 4; major++ {
+		for minor := 0; minor < 4; minor++ {
+			for patch := 0; patch < 4; patch++ {
+				for _, suffix := range []string{"", "0", "rc.1", "beta.2.x"} {
+					v := semver.New().Set(major, minor, patch, suffix)
+					w, err := semver.NewFromString(v.String())
+					if err != nil || !w.Equal(v) || w.String() != v.String() {
+						t.Errorf("%q parses as %q (%v)", v, w, err)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestCaretZeroMajor(t *testing.T) {
+	for _, tc := range []struct {
+		constraint string
+		expanded   string
+		in, out    []string
+	}{
+		{"^1.2.3", ">=1.2.3 <2.0.0-0", []string{"1.2.3", "1.9.0", "1.99.99"}, []string{"1.2.2", "2.0.0", "2.0.0-rc.1"}},
+		{"^0.2.3", ">=0.2.3 <0.3.0-0", []string{"0.2.3", "0.2.99"}, []string{"0.2.2", "0.3.0", "0.3.0-rc.1", "1.0.0"}},
+		{"^0.2", ">=0.2.0 <0.3.0-0", []string{"0.2.0", "0.2.9"}, []string{"0.1.9", "0.3.0"}},
+		{"^0.0.3", ">=0.0.3 <0.0.4-0", []string{"0.0.3"}, []string{"0.0.2", "0.0.4", "0.0.4-rc.1", "0.1.0"}},
+		{"^0.0.0", ">=0.0.0 <0.0.1-0", []string{"0.0.0"}, []string{"0.0.1", "0.1.0"}},
+		{"^0.0", ">=0.0.0 <0.1.0-0", []string{"0.0.0", "0.0.9"}, []string{"0.1.0", "1.0.0"}},
+		{"^0", ">=0.0.0 <1.0.0-0", []string{"0.0.0", "0.9.9"}, []string{"1.0.0", "1.0.0-rc.1"}},
+	} {
+		c, err := semver.NewConstraint(tc.constraint)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.constraint, err)
+		}
+		if got := c.String(); got != tc.expanded {
+			t.Errorf("%s expands to %q, want %q", tc.constraint, got, tc.expanded)
+		}
+		for _, s := range tc.in {
+			if !c.Check(semver.MustParse(s)) {
+				t.Errorf("%s should match %s", tc.constraint, s)
+			}
+		}
+		for _, s := range tc.out {
+			if c.Check(semver.MustParse(s)) {
+				t.Errorf("%s shouldn't match %s", tc.constraint, s)
+			}
+		}
+	}
+}
+
+func parseAll(t *testing.T, strs ...string) []*semver.Version {
+	t.Helper()
+	vs, err := semver.ParseAll(strs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return vs
+}
+
+// versionString returns v.String(), or "" if v is nil.
+func versionString(v *semver.Version) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
+
+func TestSearchBoundaries(t *testing.T) {
+	vs := parseAll(t, "1.0.0", "1.1.0-rc.1", "1.1.0", "1.1.0", "1.1.0", "2.0.0")
+	for _, tc := range []struct {
+		target       string
+		index        int
+		found        bool
+		below, above string // "" for nil
+	}{
+		{"0.9.0", 0, false, "", "v1.0.0"},
+		{"3.0.0", 6, false, "v2.0.0", ""},
+		{"1.0.0", 0, true, "v1.0.0", "v1.0.0"},
+		{"2.0.0", 5, true, "v2.0.0", "v2.0.0"},
+		{"1.1.0", 2, true, "v1.1.0", "v1.1.0"},
+		{"1.1.0-rc.2", 2, false, "v1.1.0-rc.1", "v1.1.0"},
+	} {
+		target := semver.MustParse(tc.target)
+		index, found := semver.Search(vs, target)
+		if index != tc.index || found != tc.found {
+			t.Errorf("Search(%s) = %d, %v, want %d, %v", tc.target, index, found, tc.index, tc.found)
+		}
+		if got := versionString(semver.NearestBelow(vs, target)); got != tc.below {
+			t.Errorf("NearestBelow(%s) = %q, want %q", tc.target, got, tc.below)
+		}
+		if got := versionString(semver.NearestAbove(vs, target)); got != tc.above {
+			t.Errorf("NearestAbove(%s) = %q, want %q", tc.target, got, tc.above)
+		}
+	}
+	// with duplicates NearestBelow returns the last and NearestAbove the first of the equal elements
+	target := semver.MustParse("1.1.0")
+	if semver.NearestBelow(vs, target) != vs[4] {
+		t.Errorf("NearestBelow(1.1.0) should return the last duplicate")
+	}
+	if semver.NearestAbove(vs, target) != vs[2] {
+		t.Errorf("NearestAbove(1.1.0) should return the first duplicate")
+	}
+	if index, found := semver.Search(nil, target); index != 0 || found {
+		t.Errorf("Search(nil) = %d, %v", index, found)
+	}
+	if semver.NearestBelow(nil, target) != nil || semver.NearestAbove(nil, target) != nil {
+		t.Errorf("nearest versions in an empty slice should be nil")
+	}
+}
+
+// TestKey locks in the output of Key, which is documented as stable across releases.
+func TestKey(t *testing.T) {
+	for _, tc := range []struct {
+		input, key string
+	}{
+		{"0", "0.0.0"},
+		{"v1", "1.0.0"},
+		{"v1.2", "1.2.0"},
+		{"1.2.0", "1.2.0"},
+		{"V1.2.3", "1.2.3"},
+		{"v1.2.0-rc.1+b", "1.2.0-rc.1"},
+		{"1.2.3+build.5", "1.2.3"},
+		{"v10.20.30-alpha.beta.1", "10.20.30-alpha.beta.1"},
+		{"1.0.0-0", "1.0.0-0"},
+	} {
+		if got := semver.MustParse(tc.input).Key(); got != tc.key {
+			t.Errorf("Key(%q) = %q, want %q", tc.input, got, tc.key)
+		}
+	}
+	if got := semver.New().Key(); got != "0.0.0" {
+		t.Errorf("New().Key() = %q, want %q", got, "0.0.0")
+	}
+	if got := semver.NewFromInts(1, 2, 0).Key(); got != "1.2.0" {
+		t.Errorf("NewFromInts(1, 2, 0).Key() = %q, want %q", got, "1.2.0")
+	}
+}
+
+// TestAtomicVersionConcurrent is meant to be run with -race.
+func TestAtomicVersionConcurrent(t *testing.T) {
+	const writers, readers, increments = 4, 4, 200
+	a := semver.NewAtomicVersion(semver.MustParse("v1.0.0"))
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < increments; {
+				old := a.Load()
+				next := old.Clone().SetPatch(old.GetPatch() + 1)
+				if a.CompareAndSwap(old, next) {
+					n++
+				}
+				// neither the versions passed in nor the ones handed out are shared with the holder
+				old.SetMajor(99)
+				next.SetSuffix("mutated")
+			}
+		}()
+	}
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			last := 0
+			for n := 0; n < writers*increments; n++ {
+				v := a.Load()
+				if v.GetMajor() != 1 || v.GetSuffix() != "" || v.GetPatch() < last {
+					t.Errorf("read %s after patch %d", v, last)
+					return
+				}
+				last = v.GetPatch()
+				v.SetMajor(42)
+			}
+		}()
+	}
+	wg.Wait()
+	if got, want := a.Load().String(), fmt.Sprintf("v1.0.%d", writers*increments); got != want {
+		t.Errorf("final version %s, want %s", got, want)
+	}
+	if a.Upgrade(semver.MustParse("v1.0.0")) || !a.Upgrade(semver.MustParse("v2.0.0")) {
+		t.Errorf("Upgrade should only accept greater versions")
+	}
+}
+
+// modKnownExceptions lists the inputs on which the Mod* functions knowingly diverge from x/mod,
+// with the reason. Components larger than MaxComponent are the only known divergence.
+var modKnownExceptions = map[string]string{
+	"v9007199254740992.0.0":     "major exceeds MaxComponent, x/mod accepts numbers of any size",
+	"v1.9007199254740992":       "minor exceeds MaxComponent",
+	"v1.2.99999999999999999999": "patch exceeds MaxComponent",
+}
+
+// exceedsMaxComponent reports whether str is valid for x/mod but has a component larger than MaxComponent.
+func exceedsMaxComponent(str string) bool {
+	core, _, _ := strings.Cut(strings.TrimPrefix(xmodCanonical(str), "v"), "-")
+	if core == "" {
+		return false
+	}
+	for _, digits := range strings.Split(core, ".") {
+		if n, err := strconv.Atoi(digits); err != nil || n > semver.MaxComponent {
+			return true
+		}
+	}
+	return false
+}
+
+// modCorpus returns inputs for the differential test against x/mod.
+func modCorpus(n int) []string {
+	corpus := []string{}
+	for _, s := range append(semver.TrickyVersionStrings(), benchVersions...) {
+		corpus = append(corpus, s, "v"+strings.TrimPrefix(s, "v"))
+	}
+	for _, v := range randomVersions(1000) {
+		corpus = append(corpus, v.String(), v.LongString(), "v"+v.Key())
+	}
+	r := rand.New(rand.NewSource(1))
+	const chars = "v0123456789..--++axZ"
+	for i := 0; i < n; i++ {
+		b := make([]byte, 1+r.Intn(16))
+		b[0] = 'v'
+		for j := 1; j < len(b); j++ {
+			b[j] = chars[r.Intn(len(chars))]
+		}
+		corpus = append(corpus, string(b))
+	}
+	return corpus
+}
+
+func TestModMatchesXMod(t *testing.T) {
+	for str, reason := range modKnownExceptions {
+		if semver.ModIsValid(str) == xmodIsValid(str) {
+			t.Errorf("%q is listed as known exception (%s) but agrees with x/mod now", str, reason)
+		}
+	}
+	corpus := modCorpus(200000)
+	valid := []string{}
+	for _, str := range corpus {
+		if exceedsMaxComponent(str) {
+			continue
+		}
+		for name, fn := range map[string][2]func(string) string{
+			"Canonical":  {semver.ModCanonical, xmodCanonical},
+			"Major":      {semver.ModMajor, xmodMajor},
+			"MajorMinor": {semver.ModMajorMinor, xmodMajorMinor},
+			"Prerelease": {semver.ModPrerelease, xmodPrerelease},
+			"Build":      {semver.ModBuild, xmodBuild},
+		} {
+			if got, want := fn[0](str), fn[1](str); got != want {
+				t.Fatalf("Mod%s(%q) = %q, x/mod returns %q", name, str, got, want)
+			}
+		}
+		if got, want := semver.ModIsValid(str), xmodIsValid(str); got != want {
+			t.Fatalf("ModIsValid(%q) = %v, x/mod returns %v", str, got, want)
+		}
+		if xmodIsValid(str) {
+			valid = append(valid, str)
+		}
+	}
+	// compare neighbours, mostly valid versions but also some invalid ones
+	pairs := append(valid, corpus[:1000]...)
+	for i := 1; i < len(pairs); i++ {
+		a, b := pairs[i-1], pairs[i]
+		if exceedsMaxComponent(a) || exceedsMaxComponent(b) {
+			continue
+		}
+		if got, want := semver.ModCompare(a, b), xmodCompare(a, b); got != want {
+			t.Fatalf("ModCompare(%q, %q) = %d, x/mod returns %d", a, b, got, want)
+		}
+	}
+}
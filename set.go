@@ -0,0 +1,88 @@
+package semver
+
+// VersionSet is a collection of unique versions, keyed by their Key. Like Equal it ignores build metadata,
+// so adding v1.0.0+b to a set holding v1.0.0+a replaces it.
+type VersionSet struct {
+	versions map[string]*Version
+}
+
+// NewVersionSet returns a set containing the given versions.
+func NewVersionSet(versions ...*Version) *VersionSet {
+	s := &VersionSet{
+		versions: map[string]*Version{},
+	}
+	for _, v := range versions {
+		s.Add(v)
+	}
+	return s
+}
+
+func (s *VersionSet) Add(v *Version) *VersionSet {
+	if v != nil {
+		s.versions[v.Key()] = v
+	}
+	return s
+}
+
+func (s *VersionSet) Remove(v *Version) *VersionSet {
+	if v != nil {
+		delete(s.versions, v.Key())
+	}
+	return s
+}
+
+func (s *VersionSet) Contains(v *Version) bool {
+	if v == nil {
+		return false
+	}
+	_, ok := s.versions[v.Key()]
+	return ok
+}
+
+func (s *VersionSet) Len() int {
+	return len(s.versions)
+}
+
+// Union returns a new set with the versions that are in s or other.
+func (s *VersionSet) Union(other *VersionSet) *VersionSet {
+	res := NewVersionSet()
+	for k, v := range s.versions {
+		res.versions[k] = v
+	}
+	for k, v := range other.versions {
+		res.versions[k] = v
+	}
+	return res
+}
+
+// Intersection returns a new set with the versions that are in both s and other.
+func (s *VersionSet) Intersection(other *VersionSet) *VersionSet {
+	res := NewVersionSet()
+	for k, v := range s.versions {
+		if _, ok := other.versions[k]; ok {
+			res.versions[k] = v
+		}
+	}
+	return res
+}
+
+// Difference returns a new set with the versions that are in s but not in other.
+func (s *VersionSet) Difference(other *VersionSet) *VersionSet {
+	res := NewVersionSet()
+	for k, v := range s.versions {
+		if _, ok := other.versions[k]; !ok {
+			res.versions[k] = v
+		}
+	}
+	return res
+}
+
+// ToSlice returns the versions of the set in sorted order.
+func (s *VersionSet) ToSlice() []*Version {
+	res := make([]*Version, 0, len(s.versions))
+	for _, v := range s.versions {
+		res = append(res, v)
+	}
+	SortVersions(res)
+	return res
+}
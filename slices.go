@@ -0,0 +1,279 @@
+package semver
+
+import "sort"
+
+type versionKey struct {
+	major  int
+	minor  int
+	patch  int
+	suffix string
+}
+
+func uniqueBy(versions []*Version, key func(v *Version) versionKey) []*Version {
+	seen := map[versionKey]bool{}
+	res := []*Version{}
+	for _, v := range versions {
+		if v == nil {
+			continue
+		}
+		k := key(v)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		res = append(res, v)
+	}
+	return res
+}
+
+// Unique returns the versions without semantic duplicates (suffix included),
+// keeping the first occurrence of each. Nil entries are dropped and the input is not modified.
+func Unique(versions []*Version) []*Version {
+	return uniqueBy(versions, func(v *Version) versionKey {
+		return versionKey{v.major, v.minor, v.patch, v.suffix}
+	})
+}
+
+// UniqueCore is like Unique but ignores the suffix, so v1.2.0-rc.1 and v1.2.0 are considered duplicates.
+func UniqueCore(versions []*Version) []*Version {
+	return uniqueBy(versions, func(v *Version) versionKey {
+		return versionKey{v.major, v.minor, v.patch, ""}
+	})
+}
+
+// Index returns the index of the first version semantically equal to target, or -1 if there is none.
+// Nil entries never match.
+func Index(versions []*Version, target *Version) int {
+	if target == nil {
+		return -1
+	}
+	for i, v := range versions {
+		if v != nil && compare(v, target) == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// Contains reports whether versions holds a version semantically equal to target.
+func Contains(versions []*Version, target *Version) bool {
+	return Index(versions, target) >= 0
+}
+
+// ContainsString parses target and reports whether versions holds an equal version.
+// It returns false if target is not a valid version.
+func ContainsString(versions []*Version, target string) bool {
+	version, err := NewFromString(target)
+	if err != nil {
+		return false
+	}
+	return Contains(versions, version)
+}
+
+// Search finds target in versions, which must be sorted (see SortVersions), using binary search.
+// It returns the index of the first version equal to target or, if there is none,
+// the index at which target would have to be inserted, and whether it was found.
+func Search(versions []*Version, target *Version) (index int, found bool) {
+	i := sort.Search(len(versions), func(i int) bool {
+		return compare(versions[i], target) >= 0
+	})
+	return i, i < len(versions) && compare(versions[i], target) == 0
+}
+
+// NearestBelow returns the newest version in the sorted slice that is less than or equal to target,
+// or nil if there is none.
+func NearestBelow(versions []*Version, target *Version) *Version {
+	i := sort.Search(len(versions), func(i int) bool {
+		return compare(versions[i], target) > 0
+	})
+	if i == 0 {
+		return nil
+	}
+	return versions[i-1]
+}
+
+// NearestAbove returns the oldest version in the sorted slice that is greater than or equal to target,
+// or nil if there is none.
+func NearestAbove(versions []*Version, target *Version) *Version {
+	i, _ := Search(versions, target)
+	if i == len(versions) {
+		return nil
+	}
+	return versions[i]
+}
+
+// GroupByMajor buckets the versions by their major version, each bucket sorted.
+// Nil entries are skipped.
+func GroupByMajor(versions []*Version) map[int][]*Version {
+	groups := map[int][]*Version{}
+	for _, v := range versions {
+		if v == nil {
+			continue
+		}
+		groups[v.major] = append(groups[v.major], v)
+	}
+	for _, group := range groups {
+		SortVersions(group)
+	}
+	return groups
+}
+
+// LatestPerMinor returns the newest version of every major.minor line, sorted ascending.
+// If includePreRelease is false versions with a suffix are ignored,
+// so lines that only have pre-releases are left out.
+func LatestPerMinor(versions []*Version, includePreRelease bool) []*Version {
+	sorted := filter(versions, func(v *Version) bool { return includePreRelease || v.suffix == "" })
+	SortVersions(sorted)
+	res := []*Version{}
+	for i, v := range sorted {
+		if i+1 < len(sorted) && sorted[i+1].major == v.major && sorted[i+1].minor == v.minor {
+			continue
+		}
+		res = append(res, v)
+	}
+	return res
+}
+
+func filter(versions []*Version, keep func(v *Version) bool) []*Version {
+	res := []*Version{}
+	for _, v := range versions {
+		if v != nil && keep(v) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// FilterStable returns the versions without a suffix, preserving their order.
+// Build metadata does not make a version a pre-release.
+func FilterStable(versions []*Version) []*Version {
+	return filter(versions, (*Version).IsStable)
+}
+
+// FilterPrerelease returns the versions with a suffix, preserving their order.
+func FilterPrerelease(versions []*Version) []*Version {
+	return filter(versions, (*Version).IsPreRelease)
+}
+
+// InsertSorted inserts v into the sorted slice and returns the resulting slice.
+// If the slice already holds versions equal to v, v is inserted after them.
+func InsertSorted(versions []*Version, v *Version) []*Version {
+	i := sort.Search(len(versions), func(i int) bool {
+		return compare(versions[i], v) > 0
+	})
+	versions = append(versions, nil)
+	copy(versions[i+1:], versions[i:])
+	versions[i] = v
+	return versions
+}
+
+// Max returns the highest version, or nil if there are no (non-nil) versions.
+func Max(versions []*Version) *Version {
+	var res *Version
+	for _, v := range versions {
+		if v != nil && (res == nil || compare(v, res) > 0) {
+			res = v
+		}
+	}
+	return res
+}
+
+// VersionStrings returns the string representation of each version, preserving their order.
+// Nil entries are skipped.
+func VersionStrings(versions []*Version) []string {
+	return VersionStringsWithOptions(versions, FormatOptions{})
+}
+
+// VersionStringsWithOptions is like VersionStrings but renders each version using the given options.
+func VersionStringsWithOptions(versions []*Version, opts FormatOptions) []string {
+	res := []string{}
+	for _, v := range versions {
+		if v != nil {
+			res = append(res, v.StringWithOptions(opts))
+		}
+	}
+	return res
+}
+
+// Changelog returns the sorted versions that are newer than from and not newer than to,
+// i.e. what changed when upgrading from one to the other. The input doesn't have to be sorted
+// and isn't modified.
+func Changelog(from, to *Version, versions []*Version) []*Version {
+	return Between(versions, from, to, false, true)
+}
+
+// Between returns the sorted versions between lo and hi. Whether lo and hi themselves are included
+// is controlled by includeLo and includeHi, a nil bound leaves that side unbounded. The input doesn't
+// have to be sorted and isn't modified.
+func Between(versions []*Version, lo, hi *Version, includeLo, includeHi bool) []*Version {
+	r := NewVersionRange(lo, hi, includeLo, includeHi)
+	res := filter(versions, r.Contains)
+	SortVersions(res)
+	return res
+}
+
+// MissingMinors returns the minor versions absent between the lowest and highest minor version
+// of each major version, e.g. v1.4.0 for [v1.3.2, v1.5.0]. Pre-releases are only taken into
+// account if includePreRelease is true. The input doesn't have to be sorted and isn't modified.
+func MissingMinors(versions []*Version, includePreRelease bool) []*Version {
+	return missing(versions, includePreRelease, func(a, b *Version) []*Version {
+		res := []*Version{}
+		if a.major == b.major {
+			for minor := a.minor + 1; minor < b.minor; minor++ {
+				res = append(res, NewFromInts(a.major, minor, 0))
+			}
+		}
+		return res
+	})
+}
+
+// MissingPatches is like MissingMinors for the patch versions absent between the lowest and highest
+// patch version of each minor version, e.g. v1.3.1 and v1.3.2 for [v1.3.0, v1.3.3].
+func MissingPatches(versions []*Version, includePreRelease bool) []*Version {
+	return missing(versions, includePreRelease, func(a, b *Version) []*Version {
+		res := []*Version{}
+		if a.major == b.major && a.minor == b.minor {
+			for patch := a.patch + 1; patch < b.patch; patch++ {
+				res = append(res, NewFromInts(a.major, a.minor, patch))
+			}
+		}
+		return res
+	})
+}
+
+// missing sorts the versions and collects the gaps that gaps finds between neighbours.
+func missing(versions []*Version, includePreRelease bool, gaps func(a, b *Version) []*Version) []*Version {
+	sorted := filter(versions, func(v *Version) bool { return includePreRelease || v.suffix == "" })
+	SortVersions(sorted)
+	res := []*Version{}
+	for i := 1; i < len(sorted); i++ {
+		res = append(res, gaps(sorted[i-1], sorted[i])...)
+	}
+	return res
+}
+
+// Negotiate returns the highest version present in both lists, or nil if they have none in common.
+// Build metadata is ignored when matching; the returned version is taken from ours. The lists don't
+// have to be sorted or deduplicated and aren't modified.
+func Negotiate(ours, theirs []*Version) *Version {
+	supported := map[versionKey]bool{}
+	for _, v := range theirs {
+		if v != nil {
+			supported[versionKey{v.major, v.minor, v.patch, v.suffix}] = true
+		}
+	}
+	return Max(filter(ours, func(v *Version) bool {
+		return supported[versionKey{v.major, v.minor, v.patch, v.suffix}]
+	}))
+}
+
+// NegotiateStable is like Negotiate but ignores pre-releases.
+func NegotiateStable(ours, theirs []*Version) *Version {
+	return Negotiate(FilterStable(ours), theirs)
+}
+
+// NegotiateConstraint returns the highest of our versions that satisfies their constraint,
+// or nil if none does.
+func NegotiateConstraint(ours []*Version, theirConstraint Checker) *Version {
+	return Max(filter(ours, theirConstraint.Check))
+}
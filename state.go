@@ -0,0 +1,36 @@
+package semver
+
+// VersionState is a snapshot of the fields of a Version. It is comparable with ==.
+type VersionState struct {
+	Major    int
+	Minor    int
+	Patch    int
+	Suffix   string
+	Metadata string
+}
+
+func (s VersionState) String() string {
+	return New().Restore(s).String()
+}
+
+// Snapshot returns the current state of the version, see Restore.
+func (v *Version) Snapshot() VersionState {
+	return VersionState{
+		Major:    v.major,
+		Minor:    v.minor,
+		Patch:    v.patch,
+		Suffix:   v.suffix,
+		Metadata: v.metadata,
+	}
+}
+
+// Restore sets the version to a state previously returned by Snapshot.
+// Components are validated like SetMajor, SetMinor and SetPatch do, but the precision is left untouched.
+func (v *Version) Restore(state VersionState) *Version {
+	v.setComponent("major", &v.major, state.Major)
+	v.setComponent("minor", &v.minor, state.Minor)
+	v.setComponent("patch", &v.patch, state.Patch)
+	v.suffix = state.Suffix
+	v.metadata = state.Metadata
+	return v
+}
@@ -0,0 +1,168 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func isTagSeparator(c byte) bool {
+	return c == '-' || c == '_' || c == '/' || c == '@' || c == '.'
+}
+
+// NewFromTag parses a version from a git tag or ref such as "refs/tags/v1.2.3",
+// "release/v1.2.3" or "project-v1.2.3-beta". Anything in front of the version is stripped,
+// the version itself has to start at the beginning of the tag or after one of "-", "_", "/", "@" or ".".
+func NewFromTag(tag string) (*Version, error) {
+	version, _, err := parseTag(tag, ParseOptions{AllowLeadingV: true, AllowBuildMeta: true})
+	return version, err
+}
+
+// parseTag returns the version found in tag and whether it has a leading "v".
+func parseTag(tag string, opts ParseOptions) (*Version, bool, error) {
+	str := strings.TrimPrefix(strings.TrimSpace(tag), "refs/tags/")
+	for i := 0; i < len(str); i++ {
+		if i > 0 && !isTagSeparator(str[i-1]) {
+			continue
+		}
+		prefixed := (str[i] == 'v' || str[i] == 'V') && i+1 < len(str) && isDigit(str[i+1])
+		if isDigit(str[i]) || prefixed {
+			version, err := NewFromStringWithOptions(str[i:], opts)
+			if err == nil {
+				return version, prefixed, nil
+			}
+		}
+	}
+	return New(), false, &InvalidVersionError{Input: tag, Reason: "no version in tag", Err: ErrInvalidFormat}
+}
+
+// GitDescribeResult is the parsed output of `git describe --tags`.
+type GitDescribeResult struct {
+	Version *Version
+	Commits int    // number of commits since the tag, 0 if the tag itself is described
+	Hash    string // abbreviated commit hash without the "g" prefix, empty if the tag itself is described
+}
+
+// ParseGitDescribe parses the output of `git describe --tags`, such as "v1.2.3", "v1.2.3-5-gabcdef0"
+// or "v1.2.3-rc.1-3-gabcdef0". The tag itself is parsed like NewFromTag does.
+func ParseGitDescribe(str string) (*GitDescribeResult, error) {
+	str = strings.TrimSpace(str)
+	res := &GitDescribeResult{}
+	tag := str
+	if i := strings.LastIndex(str, "-g"); i > 0 && isHex(str[i+2:]) {
+		if j := strings.LastIndex(str[:i], "-"); j > 0 {
+			if commits, ok := atoi(str[j+1 : i]); isNumeric(str[j+1:i]) && ok {
+				tag, res.Commits, res.Hash = str[:j], commits, str[i+2:]
+			}
+		}
+	}
+	version, err := NewFromTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	res.Version = version
+	return res, nil
+}
+
+func isHex(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if !isDigit(str[i]) && (str[i] < 'a' || str[i] > 'f') {
+			return false
+		}
+	}
+	return str != ""
+}
+
+// ErrNoVersionTags is returned by NextExistingTag if none of the tags contain a version.
+var ErrNoVersionTags = errors.New("no version tags")
+
+// NextTag finds the highest version among tags, skipping tags without a full major.minor.patch version, bumps it and
+// returns the new tag, with a leading "v" if most of the version tags have one.
+// The level is "major", "minor", "patch" (see RoundUp) or "prerelease:<identifier>", which increments
+// the number of a pre-release with that identifier (v1.2.3-rc.1 becomes v1.2.3-rc.2), switches a
+// pre-release of a lower channel to it (v1.2.3-beta.1 becomes v1.2.3-rc.1) or starts a new one on
+// the next patch version otherwise (v1.2.3 becomes v1.2.4-rc.1).
+// If the highest version is a pre-release of the version the level targets, its release is returned,
+// e.g. "patch" and "minor" turn v1.3.0-rc.1 into v1.3.0 while "major" gives v2.0.0.
+// If there are no version tags, v0.0.0 is bumped, e.g. "minor" yields "v0.1.0".
+func NextTag(tags []string, level string) (string, error) {
+	next, err := nextTag(tags, level)
+	if errors.Is(err, ErrNoVersionTags) {
+		return nextTag([]string{"v0.0.0"}, level)
+	}
+	return next, err
+}
+
+// NextExistingTag is like NextTag but returns ErrNoVersionTags if there are no version tags.
+func NextExistingTag(tags []string, level string) (string, error) {
+	return nextTag(tags, level)
+}
+
+func nextTag(tags []string, level string) (string, error) {
+	var highest *Version
+	prefixed := 0
+	total := 0
+	for _, tag := range tags {
+		version, hasPrefix, err := parseTag(tag, ParseOptions{RequireAllComponents: true, AllowLeadingV: true, AllowBuildMeta: true})
+		if err != nil {
+			continue
+		}
+		total++
+		if hasPrefix {
+			prefixed++
+		}
+		if highest == nil || compare(version, highest) > 0 {
+			highest = version
+		}
+	}
+	if highest == nil {
+		return "", ErrNoVersionTags
+	}
+	var next *Version
+	if id, ok := strings.CutPrefix(level, "prerelease:"); ok {
+		if id == "" {
+			return "", fmt.Errorf("missing pre-release identifier in %q", level)
+		}
+		next = highest.clone()
+		next.metadata = ""
+		if ids := next.PreReleaseIdentifiers(); len(ids) == 0 {
+			next = NewFromInts(highest.major, highest.minor, highest.patch+1).SetSuffix(id, "1")
+		} else if ids[0] != id {
+			next = highest.Core().SetSuffix(id, "1")
+			if compare(next, highest) <= 0 { // e.g. "beta" after "rc", which would go backwards
+				next = NewFromInts(highest.major, highest.minor, highest.patch+1).SetSuffix(id, "1")
+			}
+		} else if n, ok := atoi(ids[len(ids)-1]); ok && len(ids) > 1 && isNumeric(ids[len(ids)-1]) {
+			ids[len(ids)-1] = strconv.Itoa(n + 1)
+			next.SetSuffix(ids...)
+		} else {
+			next.SetSuffix(append(ids, "1")...)
+		}
+	} else if releasesAt(highest, level) {
+		next = highest.Core()
+	} else {
+		var err error
+		if next, err = highest.RoundUp(level); err != nil {
+			return "", err
+		}
+	}
+	return next.StringWithOptions(FormatOptions{OmitPrefix: prefixed*2 < total, AllComponents: true}), nil
+}
+
+// releasesAt reports whether bumping the pre-release v to level just releases it, like npm's semver inc:
+// v1.3.0-rc.1 already targets the minor version 1.3.0, so bumping its patch or minor version gives v1.3.0.
+func releasesAt(v *Version, level string) bool {
+	if v.suffix == "" {
+		return false
+	}
+	switch level {
+	case "major":
+		return v.minor == 0 && v.patch == 0
+	case "minor":
+		return v.patch == 0
+	case "patch":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package semver
+
+import "errors"
+
+// Validate returns all problems with the version: components outside the range 0 to MaxComponent,
+// a suffix that violates the SemVer spec (see ValidateSuffix) and invalid build metadata.
+// It returns nil for every version parsed with StrictParseOptions.
+func (v *Version) Validate() error {
+	return errors.Join(
+		checkComponent("major", v.major),
+		checkComponent("minor", v.minor),
+		checkComponent("patch", v.patch),
+		ValidateSuffix(v.suffix),
+		validateIdentifiers("metadata", v.metadata, true),
+	)
+}
+
+// Validate parses str with StrictParseOptions and validates the result.
+func Validate(str string) error {
+	version, err := NewFromStringWithOptions(str, StrictParseOptions)
+	if err != nil {
+		return err
+	}
+	return version.Validate()
+}
+
+// ValidateVersion validates a constructed version: it reports a nil version, values rejected by the
+// chainable setters (see Err) and all problems found by Validate.
+func ValidateVersion(v *Version) error {
+	if v == nil {
+		return errors.New("nil version")
+	}
+	return errors.Join(v.err, v.Validate())
+}
@@ -0,0 +1,42 @@
+package semver
+
+// Value is an immutable copy of a version without build metadata that can be used as a map key.
+// Comparing values with == requires the suffixes to be byte for byte identical, which is stricter
+// than the precedence rules of the spec; use Equal for spec-correct equality.
+// Use VersionState if the metadata has to be kept as well.
+type Value struct {
+	Major  int
+	Minor  int
+	Patch  int
+	Suffix string
+}
+
+// Comparable returns the version as a Value, dropping its build metadata.
+func (v *Version) Comparable() Value {
+	return Value{
+		Major:  v.major,
+		Minor:  v.minor,
+		Patch:  v.patch,
+		Suffix: v.suffix,
+	}
+}
+
+// Version returns a new version with the components and suffix of the value.
+// Components are set like Restore does.
+func (val Value) Version() *Version {
+	return New().Restore(VersionState{Major: val.Major, Minor: val.Minor, Patch: val.Patch, Suffix: val.Suffix})
+}
+
+// Equal reports whether val and other are semantically equal, see Version.Equal.
+func (val Value) Equal(other Value) bool {
+	return val.Compare(other) == 0
+}
+
+// Compare compares val and other like Version.Compare. It does not allocate.
+func (val Value) Compare(other Value) int {
+	return CompareRaw(val.Major, val.Minor, val.Patch, val.Suffix, other.Major, other.Minor, other.Patch, other.Suffix)
+}
+
+func (val Value) String() string {
+	return val.Version().String()
+}